@@ -0,0 +1,3 @@
+data "cloudinary_asset_versions" "logo" {
+  public_id = "brand/logo"
+}
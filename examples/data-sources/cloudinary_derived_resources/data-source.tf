@@ -0,0 +1,9 @@
+data "cloudinary_derived_resources" "trailer_streaming" {
+  public_id             = "movies/trailer"
+  resource_type         = "video"
+  transformation_prefix = "sp_"
+}
+
+output "trailer_streaming_bytes" {
+  value = data.cloudinary_derived_resources.trailer_streaming.total_bytes
+}
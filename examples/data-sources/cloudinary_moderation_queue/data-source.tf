@@ -0,0 +1,7 @@
+data "cloudinary_moderation_queue" "pending" {
+  status = "pending"
+}
+
+output "pending_public_ids" {
+  value = data.cloudinary_moderation_queue.pending.assets[*].public_id
+}
@@ -0,0 +1,4 @@
+resource "cloudinary_access_mode" "launch" {
+  prefix      = "launches/2022-autumn/"
+  access_mode = "authenticated"
+}
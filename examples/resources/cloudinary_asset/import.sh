@@ -0,0 +1 @@
+terraform import cloudinary_asset.logo image/upload/brand/logo
@@ -0,0 +1,25 @@
+resource "cloudinary_asset" "logo" {
+  source    = "${path.module}/assets/logo.png"
+  public_id = "brand/logo"
+}
+
+resource "cloudinary_asset" "intro" {
+  source_url    = "https://example.com/videos/intro.mp4"
+  public_id     = "brand/intro"
+  resource_type = "video"
+  type          = "authenticated"
+}
+
+resource "cloudinary_asset" "press" {
+  source    = "${path.module}/assets/press.jpg"
+  public_id = "press/launch"
+
+  access_control {
+    access_type = "token"
+  }
+
+  access_control {
+    access_type = "anonymous"
+    start       = "2022-11-01T09:00:00+09:00"
+  }
+}
@@ -0,0 +1,8 @@
+resource "cloudinary_asset_context" "hero" {
+  public_ids = ["banners/summer"]
+
+  context = {
+    alt     = "Beach at sunset"
+    caption = "Summer sale | up to 50% off"
+  }
+}
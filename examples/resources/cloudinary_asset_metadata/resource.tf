@@ -0,0 +1,12 @@
+resource "cloudinary_asset_metadata" "stock" {
+  public_ids = ["stock/beach", "stock/forest"]
+
+  values = {
+    licence_expiry = "2030-01-31"
+    photographer   = "Jane Doe"
+  }
+
+  set_values = {
+    channels = ["web", "print"]
+  }
+}
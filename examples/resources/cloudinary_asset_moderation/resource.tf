@@ -0,0 +1,4 @@
+resource "cloudinary_asset_moderation" "seed" {
+  public_id = "community/seed/welcome"
+  status    = "approved"
+}
@@ -0,0 +1 @@
+terraform import cloudinary_asset_relation.hero 4af2e0cf1e1c3d2b4f0a8d2e5c6b7a91
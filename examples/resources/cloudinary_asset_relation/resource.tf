@@ -0,0 +1,8 @@
+resource "cloudinary_asset_relation" "hero" {
+  asset_id = cloudinary_asset.hero.asset_id
+
+  related_assets = [
+    cloudinary_asset.side.id,
+    cloudinary_asset.spec_sheet.id,
+  ]
+}
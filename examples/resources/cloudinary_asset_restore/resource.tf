@@ -0,0 +1,4 @@
+resource "cloudinary_asset_restore" "logo" {
+  public_id  = "brand/logo"
+  version_id = data.cloudinary_asset_versions.logo.versions[1].version_id
+}
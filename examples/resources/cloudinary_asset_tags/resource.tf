@@ -0,0 +1,4 @@
+resource "cloudinary_asset_tags" "homepage" {
+  public_ids = ["banners/summer", "banners/winter"]
+  tags       = ["homepage", "carousel"]
+}
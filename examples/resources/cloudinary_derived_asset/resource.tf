@@ -0,0 +1,15 @@
+resource "cloudinary_derived_asset" "trailer" {
+  public_id       = "movies/trailer"
+  resource_type   = "video"
+  transformations = ["c_scale,w_1920", "c_scale,w_1280", "c_scale,w_640"]
+  format          = "mp4"
+  eager_async     = true
+
+  timeouts = {
+    create = "1h"
+  }
+}
+
+output "trailer_urls" {
+  value = cloudinary_derived_asset.trailer.derived[*].secure_url
+}
@@ -0,0 +1 @@
+terraform import cloudinary_folder.products products
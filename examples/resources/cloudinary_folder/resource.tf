@@ -0,0 +1,8 @@
+resource "cloudinary_folder" "products" {
+  path = "products"
+}
+
+resource "cloudinary_upload_mapping" "products" {
+  folder   = cloudinary_folder.products.path
+  template = "https://example.com/products/"
+}
@@ -0,0 +1,2 @@
+# <field_external_id>/<external_id>
+terraform import cloudinary_metadata_datasource_entry.acme brand/acme
@@ -0,0 +1,11 @@
+resource "cloudinary_metadata_field" "brand" {
+  external_id = "brand"
+  type        = "enum"
+  label       = "Brand"
+}
+
+resource "cloudinary_metadata_datasource_entry" "acme" {
+  field_external_id = cloudinary_metadata_field.brand.external_id
+  external_id       = "acme"
+  value             = "ACME"
+}
@@ -0,0 +1 @@
+terraform import cloudinary_metadata_field.color color
@@ -0,0 +1,41 @@
+resource "cloudinary_metadata_field" "rating" {
+  external_id   = "rating"
+  type          = "integer"
+  label         = "Rating"
+  mandatory     = true
+  default_value = "3"
+
+  validation {
+    type = "and"
+
+    rule {
+      type   = "greater_than"
+      value  = "1"
+      equals = true
+    }
+
+    rule {
+      type   = "less_than"
+      value  = "5"
+      equals = true
+    }
+  }
+}
+
+resource "cloudinary_metadata_field" "color" {
+  external_id = "color"
+  type        = "enum"
+  label       = "Color"
+
+  datasource {
+    value {
+      external_id = "red"
+      value       = "Red"
+    }
+
+    value {
+      external_id = "green"
+      value       = "Green"
+    }
+  }
+}
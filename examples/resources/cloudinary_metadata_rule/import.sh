@@ -0,0 +1 @@
+terraform import cloudinary_metadata_rule.shoe_size <rule external ID>
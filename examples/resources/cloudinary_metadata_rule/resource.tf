@@ -0,0 +1,14 @@
+resource "cloudinary_metadata_rule" "shoe_size" {
+  metadata_field_id = cloudinary_metadata_field.size.external_id
+  name              = "Show size for shoes"
+
+  condition {
+    metadata_field_id = cloudinary_metadata_field.category.external_id
+    equals            = "shoes"
+  }
+
+  result {
+    enable        = true
+    set_mandatory = true
+  }
+}
@@ -0,0 +1 @@
+terraform import cloudinary_named_transformation.thumb thumb
@@ -0,0 +1,22 @@
+resource "cloudinary_named_transformation" "thumb" {
+  name               = "thumb"
+  transformation     = "c_fill,g_auto,h_150,w_150"
+  allowed_for_strict = true
+}
+
+resource "cloudinary_named_transformation" "hero" {
+  name = "hero"
+
+  step {
+    crop    = "fill"
+    gravity = "auto"
+    width   = "1600"
+    height  = "900"
+  }
+
+  step {
+    effect  = "sharpen"
+    quality = "auto"
+    format  = "auto"
+  }
+}
@@ -0,0 +1 @@
+terraform import cloudinary_streaming_profile.example custom_hd
@@ -0,0 +1,10 @@
+resource "cloudinary_streaming_profile" "example" {
+  name         = "custom_hd"
+  display_name = "Custom HD"
+
+  representation = [
+    "br_5m,c_limit,h_1080,vc_h264:main:4.0,w_1920",
+    "br_3m,c_limit,h_720,vc_h264:main:3.1,w_1280",
+    "br_800k,c_limit,h_360,vc_h264:baseline:3.0,w_640",
+  ]
+}
@@ -0,0 +1 @@
+terraform import cloudinary_upload_preset.example example
@@ -0,0 +1,9 @@
+resource "cloudinary_upload_preset" "example" {
+  name            = "example"
+  unsigned        = true
+  folder          = "example"
+  tags            = ["example", "terraform"]
+  allowed_formats = ["jpg", "png", "webp"]
+  transformation  = "c_limit,h_2000,w_2000"
+  eager           = ["c_fill,h_200,w_200"]
+}
@@ -0,0 +1,12 @@
+resource "cloudinary_video_streaming" "trailer" {
+  public_id         = "movies/trailer"
+  streaming_profile = cloudinary_streaming_profile.example.name
+
+  timeouts = {
+    create = "1h"
+  }
+}
+
+output "trailer_hls_url" {
+  value = cloudinary_video_streaming.trailer.hls_url
+}
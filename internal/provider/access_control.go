@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	anonymousAccessType = "anonymous"
+	tokenAccessType     = "token"
+)
+
+// accessControlData is a single access type of an asset, which grants access
+// either with a token or anonymously within an optional time window.
+type accessControlData struct {
+	AccessType types.String `tfsdk:"access_type"`
+	End        types.String `tfsdk:"end"`
+	Start      types.String `tfsdk:"start"`
+}
+
+func accessControlBlock(description string) tfsdk.Block {
+	return tfsdk.Block{
+		MarkdownDescription: description,
+		NestingMode:         tfsdk.BlockNestingModeList,
+		Attributes: map[string]tfsdk.Attribute{
+			"access_type": {
+				MarkdownDescription: "Either `token`, which requires a token to access the asset, or `anonymous`, which allows anyone to access the asset within the time window.",
+				Required:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{anonymousAccessType, tokenAccessType}},
+				},
+			},
+			"end": {
+				MarkdownDescription: "The RFC 3339 timestamp when anonymous access ends.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					rfc3339Validator{},
+				},
+			},
+			"start": {
+				MarkdownDescription: "The RFC 3339 timestamp when anonymous access starts.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					rfc3339Validator{},
+				},
+			},
+		},
+	}
+}
+
+// validateAccessControl validates the time windows of access types, which
+// only anonymous access has.
+func validateAccessControl(p path.Path, entries []accessControlData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for i, e := range entries {
+		if e.AccessType.Value == tokenAccessType && (!e.Start.Null || !e.End.Null) {
+			diags.AddAttributeError(
+				p.AtListIndex(i),
+				"Invalid Attribute Combination",
+				"Token access has no time window, start and end can only be set with anonymous access.",
+			)
+			continue
+		}
+
+		if e.Start.Null || e.Start.Unknown || e.End.Null || e.End.Unknown {
+			continue
+		}
+
+		start, err := time.Parse(time.RFC3339, e.Start.Value)
+		if err != nil {
+			continue
+		}
+
+		end, err := time.Parse(time.RFC3339, e.End.Value)
+		if err != nil {
+			continue
+		}
+
+		if !end.After(start) {
+			diags.AddAttributeError(
+				p.AtListIndex(i).AtName("end"),
+				"Invalid Attribute Value",
+				fmt.Sprintf("The end %s must be later than the start %s.", e.End.Value, e.Start.Value),
+			)
+		}
+	}
+
+	return diags
+}
+
+// expandAccessControl returns the access types in the format of the API.
+func expandAccessControl(entries []accessControlData) []map[string]string {
+	expanded := make([]map[string]string, 0, len(entries))
+
+	for _, e := range entries {
+		m := map[string]string{"access_type": e.AccessType.Value}
+		if !e.Start.Null && !e.Start.Unknown {
+			m["start"] = e.Start.Value
+		}
+		if !e.End.Null && !e.End.Unknown {
+			m["end"] = e.End.Value
+		}
+		expanded = append(expanded, m)
+	}
+
+	return expanded
+}
+
+// flattenAccessControl returns the access types returned by the API. The
+// timestamps of current are kept when they denote the same instants, and
+// unrestricted anonymous access, which Cloudinary returns for assets without
+// access control, is left out unless it is configured.
+func flattenAccessControl(current []accessControlData, v interface{}) []accessControlData {
+	entries := []accessControlData{}
+
+	values, _ := v.([]interface{})
+	for _, value := range values {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		e := accessControlData{
+			AccessType: settingString(m, "access_type"),
+			End:        settingString(m, "end"),
+			Start:      settingString(m, "start"),
+		}
+
+		if i := len(entries); i < len(current) {
+			e.Start = semanticTimestamp(current[i].Start, e.Start)
+			e.End = semanticTimestamp(current[i].End, e.End)
+		}
+
+		entries = append(entries, e)
+	}
+
+	if len(current) == 0 && len(entries) == 1 && entries[0].AccessType.Value == anonymousAccessType && entries[0].Start.Null && entries[0].End.Null {
+		return []accessControlData{}
+	}
+
+	return entries
+}
+
+// semanticTimestamp returns current if it denotes the same instant as the
+// timestamp returned by the API, which may use another time zone.
+func semanticTimestamp(current types.String, remote types.String) types.String {
+	if current.Null || current.Unknown || remote.Null {
+		return remote
+	}
+
+	a, err := time.Parse(time.RFC3339, current.Value)
+	if err != nil {
+		return remote
+	}
+
+	b, err := time.Parse(time.RFC3339, remote.Value)
+	if err != nil || !a.Equal(b) {
+		return remote
+	}
+
+	return current
+}
+
+// accessControlEqual reports whether a and b are the same access types.
+func accessControlEqual(a []accessControlData, b []accessControlData) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !a[i].AccessType.Equal(b[i].AccessType) || !a[i].Start.Equal(b[i].Start) || !a[i].End.Equal(b[i].End) {
+			return false
+		}
+	}
+
+	return true
+}
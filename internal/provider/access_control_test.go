@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateAccessControl(t *testing.T) {
+	tests := []struct {
+		entries []accessControlData
+		valid   bool
+	}{
+		{
+			[]accessControlData{
+				{AccessType: types.String{Value: "token"}, Start: types.String{Null: true}, End: types.String{Null: true}},
+				{AccessType: types.String{Value: "anonymous"}, Start: types.String{Value: "2030-01-01T09:00:00+09:00"}, End: types.String{Value: "2030-01-01T01:00:00Z"}},
+			},
+			true,
+		},
+		{
+			[]accessControlData{
+				{AccessType: types.String{Value: "anonymous"}, Start: types.String{Value: "2030-01-01T09:00:00+09:00"}, End: types.String{Value: "2030-01-01T00:00:00Z"}},
+			},
+			false,
+		},
+		{
+			[]accessControlData{
+				{AccessType: types.String{Value: "token"}, Start: types.String{Value: "2030-01-01T00:00:00Z"}, End: types.String{Null: true}},
+			},
+			false,
+		},
+		{
+			[]accessControlData{
+				{AccessType: types.String{Value: "anonymous"}, Start: types.String{Unknown: true}, End: types.String{Value: "2030-01-01T00:00:00Z"}},
+			},
+			true,
+		},
+	}
+
+	for i, tt := range tests {
+		diags := validateAccessControl(path.Root("access_control"), tt.entries)
+
+		if got := !diags.HasError(); got != tt.valid {
+			t.Errorf("%d: validateAccessControl() valid = %t, want %t: %v", i, got, tt.valid, diags)
+		}
+	}
+}
+
+func TestFlattenAccessControl(t *testing.T) {
+	remote := []interface{}{
+		map[string]interface{}{"access_type": "token"},
+		map[string]interface{}{"access_type": "anonymous", "start": "2030-01-01T00:00:00Z", "end": "2030-02-01T00:00:00Z"},
+	}
+
+	current := []accessControlData{
+		{AccessType: types.String{Value: "token"}, Start: types.String{Null: true}, End: types.String{Null: true}},
+		{AccessType: types.String{Value: "anonymous"}, Start: types.String{Value: "2030-01-01T09:00:00+09:00"}, End: types.String{Value: "2030-01-31T00:00:00Z"}},
+	}
+
+	want := []accessControlData{
+		{AccessType: types.String{Value: "token"}, Start: types.String{Null: true}, End: types.String{Null: true}},
+		{AccessType: types.String{Value: "anonymous"}, Start: types.String{Value: "2030-01-01T09:00:00+09:00"}, End: types.String{Value: "2030-02-01T00:00:00Z"}},
+	}
+
+	if got := flattenAccessControl(current, remote); !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenAccessControl() = %v, want %v", got, want)
+	}
+
+	public := []interface{}{map[string]interface{}{"access_type": "anonymous"}}
+
+	if got := flattenAccessControl(nil, public); len(got) != 0 {
+		t.Errorf("flattenAccessControl(nil, public) = %v, want none", got)
+	}
+
+	if got := flattenAccessControl(nil, nil); got == nil || len(got) != 0 {
+		t.Errorf("flattenAccessControl(nil, nil) = %#v, want empty", got)
+	}
+}
@@ -0,0 +1,462 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go"
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/cloudinary/cloudinary-go/api/admin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	publicAccessMode        = "public"
+	authenticatedAccessMode = "authenticated"
+
+	// maxAccessModePublicIDs is the number of public IDs accepted by an
+	// update access mode call.
+	maxAccessModePublicIDs = 100
+
+	// maxListedAssets is the number of assets returned by a single list
+	// assets call.
+	maxListedAssets = 500
+)
+
+type accessModeResourceType struct{}
+
+func (t accessModeResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Access Mode resource. Switches the access mode of the uploaded assets matching a prefix, a tag or a list of public IDs. Only the assets whose access mode was changed are recorded, and they are switched back to their prior access mode on destroy. Assets matching the selection later on are not changed until the resource is replaced.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"access_mode": {
+				MarkdownDescription: "The access mode to apply. Either `public` or `authenticated`.",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{authenticatedAccessMode, publicAccessMode}},
+				},
+			},
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"prefix": {
+				MarkdownDescription: "Applies the access mode to the assets whose public IDs start with the prefix. Exactly one of `prefix`, `tag` or `public_ids` must be set.",
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"public_ids": {
+				MarkdownDescription: "Applies the access mode to the assets with the public IDs.",
+				Optional:            true,
+				Type:                types.SetType{ElemType: types.StringType},
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"resource_type": {
+				MarkdownDescription: "The resource type of the assets. One of `image` (default), `video` or `raw`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"image", "raw", "video"}},
+				},
+			},
+			"tag": {
+				MarkdownDescription: "Applies the access mode to the assets with the tag.",
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"updated_public_ids": {
+				MarkdownDescription: "The public IDs of the assets whose access mode was changed by the resource.",
+				Computed:            true,
+				Type:                types.SetType{ElemType: types.StringType},
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+		},
+	}, nil
+}
+
+func (t accessModeResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return accessModeResource{
+		provider: provider,
+	}, diags
+}
+
+type accessModeResourceData struct {
+	AccessMode       types.String `tfsdk:"access_mode"`
+	ID               types.String `tfsdk:"id"`
+	Prefix           types.String `tfsdk:"prefix"`
+	PublicIDs        types.Set    `tfsdk:"public_ids"`
+	ResourceType     types.String `tfsdk:"resource_type"`
+	Tag              types.String `tfsdk:"tag"`
+	UpdatedPublicIDs types.Set    `tfsdk:"updated_public_ids"`
+}
+
+// setDefaults fills the optional attributes which are not configured with
+// their default values.
+func (data *accessModeResourceData) setDefaults() {
+	if data.ResourceType.Null || data.ResourceType.Unknown {
+		data.ResourceType = types.String{Value: "image"}
+	}
+}
+
+// updateAccessModeParams are the parameters of the update access mode call.
+type updateAccessModeParams struct {
+	AccessMode string   `json:"access_mode"`
+	PublicIDs  []string `json:"public_ids"`
+}
+
+// updateAccessModeResult is the result of the update access mode call.
+type updateAccessModeResult struct {
+	Updated []struct {
+		PublicID   string `json:"public_id"`
+		AccessMode string `json:"access_mode"`
+	} `json:"updated"`
+	Failed []struct {
+		PublicID string `json:"public_id"`
+		Error    string `json:"error"`
+	} `json:"failed"`
+	Error api.ErrorResp `json:"error,omitempty"`
+}
+
+type accessModeResource struct {
+	provider provider
+}
+
+func (r accessModeResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data accessModeResourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	n := 0
+	for _, null := range []bool{data.Prefix.Null, data.Tag.Null, data.PublicIDs.Null} {
+		if !null {
+			n++
+		}
+	}
+
+	if n != 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("prefix"),
+			"Invalid Attribute Combination",
+			"Exactly one of prefix, tag or public_ids must be set.",
+		)
+	}
+}
+
+func (r accessModeResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data accessModeResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setDefaults()
+
+	assets, diags := r.matchingAssets(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the assets which are switched are recorded, so that assets which
+	// already had the access mode are left alone on destroy.
+	var publicIDs []string
+	for _, asset := range assets {
+		if asset.AccessMode != data.AccessMode.Value {
+			publicIDs = append(publicIDs, asset.PublicID)
+		}
+	}
+
+	updated, failed, err := updateAccessMode(ctx, r.provider.client, data.ResourceType.Value, data.AccessMode.Value, publicIDs)
+
+	data.ID = types.String{Value: accessModeID(data)}
+	data.UpdatedPublicIDs = stringSet(updated)
+
+	tflog.Trace(ctx, "created a resource", map[string]interface{}{
+		"updated": len(updated),
+	})
+
+	// The state is saved even if some assets could not be switched, so that
+	// the switched ones are reverted on destroy.
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update access mode, got error: %s", err),
+		)
+		return
+	}
+
+	if len(failed) > 0 {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update access mode of %s.", strings.Join(failed, ", ")),
+		)
+	}
+}
+
+func (r accessModeResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data accessModeResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var publicIDs []string
+	resp.Diagnostics.Append(data.UpdatedPublicIDs.ElementsAs(ctx, &publicIDs, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assets, diags := getAssetsByIDs(ctx, r.provider, data.ResourceType.Value, "upload", publicIDs, admin.AssetsByIDsParams{})
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Assets which have been switched back outside of Terraform have nothing
+	// left to revert.
+	found := make([]string, 0, len(assets))
+	for _, asset := range assets {
+		if asset.AccessMode == data.AccessMode.Value {
+			found = append(found, asset.PublicID)
+		}
+	}
+
+	// When every asset has been switched back, the resource is replaced so
+	// that the access mode is applied again.
+	if len(found) == 0 && len(assets) > 0 {
+		data.AccessMode = types.String{Value: assets[0].AccessMode}
+	}
+
+	data.UpdatedPublicIDs = stringSet(found)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r accessModeResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data accessModeResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every attribute requires replacement, so there is nothing to update.
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r accessModeResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data accessModeResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var publicIDs []string
+	resp.Diagnostics.Append(data.UpdatedPublicIDs.ElementsAs(ctx, &publicIDs, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior := publicAccessMode
+	if data.AccessMode.Value == publicAccessMode {
+		prior = authenticatedAccessMode
+	}
+
+	_, failed, err := updateAccessMode(ctx, r.provider.client, data.ResourceType.Value, prior, publicIDs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to revert access mode, got error: %s", err),
+		)
+		return
+	}
+
+	// Assets which have been deleted in the meantime have nothing to revert.
+	if len(failed) > 0 {
+		tflog.Warn(ctx, "unable to revert access mode of some assets", map[string]interface{}{
+			"public_ids": failed,
+		})
+	}
+}
+
+// matchingAssets lists the uploaded assets selected by the prefix, the tag or
+// the public IDs.
+func (r accessModeResource) matchingAssets(ctx context.Context, data accessModeResourceData) ([]api.BriefAssetResult, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !data.PublicIDs.Null {
+		var publicIDs []string
+		diags.Append(data.PublicIDs.ElementsAs(ctx, &publicIDs, false)...)
+
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		return getAssetsByIDs(ctx, r.provider, data.ResourceType.Value, "upload", publicIDs, admin.AssetsByIDsParams{})
+	}
+
+	var (
+		assets []api.BriefAssetResult
+		cursor string
+	)
+
+	for {
+		var (
+			res *admin.AssetsResult
+			err error
+		)
+
+		if !data.Tag.Null {
+			res, err = r.provider.client.Admin.AssetsByTag(ctx, admin.AssetsByTagParams{
+				AssetType:  api.AssetType(data.ResourceType.Value),
+				Tag:        data.Tag.Value,
+				MaxResults: maxListedAssets,
+				NextCursor: cursor,
+			})
+		} else {
+			res, err = r.provider.client.Admin.Assets(ctx, admin.AssetsParams{
+				AssetType:    api.AssetType(data.ResourceType.Value),
+				DeliveryType: "upload",
+				Prefix:       data.Prefix.Value,
+				MaxResults:   maxListedAssets,
+				NextCursor:   cursor,
+			})
+		}
+
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to list assets, got error: %s", err),
+			)
+			return nil, diags
+		}
+
+		if res.Error.Message != "" {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to list assets, got error: %s", res.Error.Message),
+			)
+			return nil, diags
+		}
+
+		// Assets with a tag are listed regardless of their delivery type.
+		for _, asset := range res.Assets {
+			if asset.Type == "upload" {
+				assets = append(assets, asset)
+			}
+		}
+
+		if res.NextCursor == "" {
+			return assets, diags
+		}
+
+		cursor = res.NextCursor
+	}
+}
+
+// updateAccessMode switches the access mode of the uploaded assets and
+// returns the public IDs of the assets which were switched and of those which
+// could not be.
+func updateAccessMode(ctx context.Context, client *cloudinary.Cloudinary, resourceType string, accessMode string, publicIDs []string) ([]string, []string, error) {
+	var updated, failed []string
+
+	for _, batch := range batchStrings(publicIDs, maxAccessModePublicIDs) {
+		var res updateAccessModeResult
+
+		err := callAdminAPI(ctx, client, http.MethodPost, api.BuildPath("resources", resourceType, "upload", "update_access_mode"), updateAccessModeParams{
+			AccessMode: accessMode,
+			PublicIDs:  batch,
+		}, &res)
+		if err != nil {
+			return updated, failed, err
+		}
+
+		if res.Error.Message != "" {
+			return updated, failed, fmt.Errorf("%s", res.Error.Message)
+		}
+
+		for _, u := range res.Updated {
+			updated = append(updated, u.PublicID)
+		}
+
+		for _, f := range res.Failed {
+			failed = append(failed, f.PublicID)
+		}
+	}
+
+	return updated, failed, nil
+}
+
+// accessModeID returns the ID of an access mode resource, made of the
+// resource type and the selection.
+func accessModeID(data accessModeResourceData) string {
+	switch {
+	case !data.Prefix.Null:
+		return api.BuildPath(data.ResourceType.Value, "prefix", data.Prefix.Value)
+	case !data.Tag.Null:
+		return api.BuildPath(data.ResourceType.Value, "tag", data.Tag.Value)
+	default:
+		var publicIDs []string
+		for _, v := range data.PublicIDs.Elems {
+			publicIDs = append(publicIDs, v.(types.String).Value)
+		}
+		return assetsID(data.ResourceType.Value, "upload", publicIDs)
+	}
+}
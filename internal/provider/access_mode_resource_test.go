@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAccessModeResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAccessModeResourceConfig("authenticated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_access_mode.test", "id", "image/prefix/terraform_acc_test/access_mode/"),
+					resource.TestCheckResourceAttr("cloudinary_access_mode.test", "updated_public_ids.#", "1"),
+				),
+			},
+			// Replace and Read testing
+			{
+				Config: testAccAccessModeResourceConfig("public"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_access_mode.test", "updated_public_ids.#", "0"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAccessModeResourceConfig(accessMode string) string {
+	return fmt.Sprintf(`
+resource "cloudinary_asset" "test" {
+  content_base64 = %[1]q
+  public_id      = "terraform_acc_test/access_mode/pixel"
+}
+
+resource "cloudinary_access_mode" "test" {
+  prefix      = "terraform_acc_test/access_mode/"
+  access_mode = %[2]q
+
+  depends_on = [cloudinary_asset.test]
+}
+`, testAccRedPixel, accessMode)
+}
+
+func TestUpdateAccessMode(t *testing.T) {
+	var requests []updateAccessModeParams
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1_1/demo/resources/image/upload/update_access_mode" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+
+		var params updateAccessModeParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			t.Error(err)
+			return
+		}
+		requests = append(requests, params)
+
+		fmt.Fprint(w, `{"updated":[`)
+		for i, publicID := range params.PublicIDs[1:] {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"public_id":%q,"access_mode":%q}`, publicID, params.AccessMode)
+		}
+		fmt.Fprintf(w, `],"failed":[{"public_id":%q,"error":"not found"}]}`, params.PublicIDs[0])
+	}))
+	defer srv.Close()
+
+	client, err := cloudinary.NewFromParams("demo", "key", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Admin.Config.API.UploadPrefix = srv.URL
+
+	publicIDs := make([]string, maxAccessModePublicIDs+2)
+	for i := range publicIDs {
+		publicIDs[i] = fmt.Sprintf("asset%d", i)
+	}
+
+	updated, failed, err := updateAccessMode(context.Background(), client, "image", authenticatedAccessMode, publicIDs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requests) != 2 || requests[0].AccessMode != authenticatedAccessMode {
+		t.Fatalf("requests = %v", requests)
+	}
+
+	if want := []string{"asset0", fmt.Sprintf("asset%d", maxAccessModePublicIDs)}; !reflect.DeepEqual(failed, want) {
+		t.Errorf("failed = %v, want %v", failed, want)
+	}
+
+	if len(updated) != maxAccessModePublicIDs {
+		t.Errorf("len(updated) = %d, want %d", len(updated), maxAccessModePublicIDs)
+	}
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go"
+	"github.com/cloudinary/cloudinary-go/api"
+)
+
+// callAdminAPI sends a request to an Admin API endpoint that is not (or not
+// completely) covered by the cloudinary-go client. Requests and responses are
+// encoded the same way the client encodes its own Admin API calls, so result
+// types can embed api.ErrorResp and be checked like any other client result.
+func callAdminAPI(ctx context.Context, client *cloudinary.Cloudinary, method string, path string, params interface{}, result interface{}) error {
+	cfg := client.Admin.Config
+
+	var body io.Reader
+	if method != http.MethodGet && params != nil {
+		b, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(b)
+	}
+
+	req, err := http.NewRequest(
+		method,
+		fmt.Sprintf("%s/%s/%s", api.BaseURL(cfg.API.UploadPrefix), cfg.Cloud.CloudName, path),
+		body,
+	)
+	if err != nil {
+		return err
+	}
+
+	if method == http.MethodGet && params != nil {
+		query, err := api.StructToParams(params)
+		if err != nil {
+			return err
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+
+	req.Header.Set("User-Agent", api.GetUserAgent())
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	if cfg.Cloud.OAuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.Cloud.OAuthToken)
+	} else {
+		req.SetBasicAuth(cfg.Cloud.APIKey, cfg.Cloud.APISecret)
+	}
+
+	if cfg.API.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.API.Timeout)*time.Second)
+		defer cancel()
+	}
+
+	resp, err := client.Admin.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer api.DeferredClose(resp.Body)
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, result); err != nil {
+		return fmt.Errorf("unexpected response (HTTP %d): %s", resp.StatusCode, b)
+	}
+
+	return nil
+}
+
+// flattenValue returns a scalar of a decoded JSON response as a string. Numbers
+// are formatted without an exponent or trailing zeros.
+func flattenValue(v interface{}) string {
+	if f, ok := v.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	return fmt.Sprint(v)
+}
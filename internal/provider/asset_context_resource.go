@@ -0,0 +1,495 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/cloudinary/cloudinary-go/api/admin"
+	"github.com/cloudinary/cloudinary-go/api/uploader"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxContextPublicIDs is the number of public IDs accepted by a context
+// call.
+const maxContextPublicIDs = 1000
+
+// contextEscaper escapes the separators of context entries, and the escape
+// character itself.
+var contextEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, `|`, `\|`)
+
+type assetContextResourceType struct{}
+
+func (t assetContextResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Asset Context resource. Manages contextual metadata entries of existing assets without managing the assets themselves. Entries which are not managed by the resource are kept.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"context": {
+				MarkdownDescription: "The contextual metadata entries of the assets, e.g. `alt` and `caption`.",
+				Required:            true,
+				Type:                types.MapType{ElemType: types.StringType},
+			},
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"public_ids": {
+				MarkdownDescription: "The public IDs of the assets.",
+				Required:            true,
+				Type:                types.SetType{ElemType: types.StringType},
+			},
+			"resource_type": {
+				MarkdownDescription: "The resource type of the assets. One of `image` (default), `video` or `raw`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"image", "raw", "video"}},
+				},
+			},
+			"type": {
+				MarkdownDescription: "The delivery type of the assets. One of `upload` (default), `private` or `authenticated`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"authenticated", "private", "upload"}},
+				},
+			},
+		},
+	}, nil
+}
+
+func (t assetContextResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return assetContextResource{
+		provider: provider,
+	}, diags
+}
+
+type assetContextResourceData struct {
+	Context      types.Map    `tfsdk:"context"`
+	ID           types.String `tfsdk:"id"`
+	PublicIDs    types.Set    `tfsdk:"public_ids"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	Type         types.String `tfsdk:"type"`
+}
+
+// setDefaults fills the optional attributes which are not configured with
+// their default values.
+func (data *assetContextResourceData) setDefaults() {
+	if data.ResourceType.Null || data.ResourceType.Unknown {
+		data.ResourceType = types.String{Value: "image"}
+	}
+	if data.Type.Null || data.Type.Unknown {
+		data.Type = types.String{Value: "upload"}
+	}
+}
+
+type assetContextResource struct {
+	provider provider
+}
+
+func (r assetContextResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data assetContextResourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for key := range data.Context.Elems {
+		if key == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("context"),
+				"Invalid Attribute Value",
+				"Context keys must not be empty.",
+			)
+		}
+	}
+}
+
+func (r assetContextResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data assetContextResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setDefaults()
+
+	var publicIDs []string
+	entries := map[string]string{}
+	resp.Diagnostics.Append(data.PublicIDs.ElementsAs(ctx, &publicIDs, false)...)
+	resp.Diagnostics.Append(data.Context.ElementsAs(ctx, &entries, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.add(ctx, data, entries, publicIDs)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.String{Value: assetsID(data.ResourceType.Value, data.Type.Value, publicIDs)}
+
+	tflog.Trace(ctx, "created a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetContextResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data assetContextResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var publicIDs []string
+	entries := map[string]string{}
+	resp.Diagnostics.Append(data.PublicIDs.ElementsAs(ctx, &publicIDs, false)...)
+	resp.Diagnostics.Append(data.Context.ElementsAs(ctx, &entries, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assets, diags := getAssetsByIDs(ctx, r.provider, data.ResourceType.Value, data.Type.Value, publicIDs, admin.AssetsByIDsParams{Context: true})
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(assets) == 0 {
+		tflog.Warn(ctx, "assets not found, removing the context from the state", map[string]interface{}{
+			"id": data.ID.Value,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	found := make([]string, 0, len(assets))
+	assetEntries := make([]map[string]string, 0, len(assets))
+	for _, asset := range assets {
+		found = append(found, asset.PublicID)
+		assetEntries = append(assetEntries, customContext(asset.Context))
+	}
+
+	data.PublicIDs = stringSet(found)
+	data.Context = stringMap(observedEntries(entries, assetEntries))
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetContextResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, state assetContextResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setDefaults()
+
+	var publicIDs, currentPublicIDs []string
+	entries, currentEntries := map[string]string{}, map[string]string{}
+	resp.Diagnostics.Append(data.PublicIDs.ElementsAs(ctx, &publicIDs, false)...)
+	resp.Diagnostics.Append(data.Context.ElementsAs(ctx, &entries, false)...)
+	resp.Diagnostics.Append(state.PublicIDs.ElementsAs(ctx, &currentPublicIDs, false)...)
+	resp.Diagnostics.Append(state.Context.ElementsAs(ctx, &currentEntries, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var currentKeys, removedKeys []string
+	for key := range currentEntries {
+		currentKeys = append(currentKeys, key)
+		if _, ok := entries[key]; !ok {
+			removedKeys = append(removedKeys, key)
+		}
+	}
+
+	// Assets which are no longer managed lose the entries of the resource.
+	resp.Diagnostics.Append(r.remove(ctx, data, currentKeys, difference(currentPublicIDs, publicIDs))...)
+	resp.Diagnostics.Append(r.remove(ctx, data, removedKeys, publicIDs)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.add(ctx, data, entries, publicIDs)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.String{Value: assetsID(data.ResourceType.Value, data.Type.Value, publicIDs)}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetContextResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data assetContextResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var publicIDs []string
+	entries := map[string]string{}
+	resp.Diagnostics.Append(data.PublicIDs.ElementsAs(ctx, &publicIDs, false)...)
+	resp.Diagnostics.Append(data.Context.ElementsAs(ctx, &entries, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+
+	resp.Diagnostics.Append(r.remove(ctx, data, keys, publicIDs)...)
+}
+
+// add adds the entries to the context of the assets, replacing the values of
+// existing keys.
+func (r assetContextResource) add(ctx context.Context, data assetContextResourceData, entries map[string]string, publicIDs []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if len(entries) == 0 || len(publicIDs) == 0 {
+		return diags
+	}
+
+	for _, batch := range batchStrings(publicIDs, maxContextPublicIDs) {
+		res, err := r.provider.client.Upload.AddContext(ctx, uploader.AddContextParams{
+			Context:      escapeContext(entries),
+			PublicIDs:    batch,
+			Type:         data.Type.Value,
+			ResourceType: data.ResourceType.Value,
+		})
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to add context, got error: %s", err),
+			)
+			return diags
+		}
+
+		if res.Error.Message != "" {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to add context, got error: %s", res.Error.Message),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// remove removes the keys from the context of the assets. The context API
+// can only remove all entries of an asset, so the context of each asset is
+// replaced at once with the entries which are kept instead.
+func (r assetContextResource) remove(ctx context.Context, data assetContextResourceData, keys []string, publicIDs []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if len(keys) == 0 || len(publicIDs) == 0 {
+		return diags
+	}
+
+	assets, diags := getAssetsByIDs(ctx, r.provider, data.ResourceType.Value, data.Type.Value, publicIDs, admin.AssetsByIDsParams{Context: true})
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, asset := range assets {
+		entries := customContext(asset.Context)
+
+		kept := map[string]string{}
+		for key, value := range entries {
+			if !containsString(keys, key) {
+				kept[key] = value
+			}
+		}
+
+		if len(kept) == len(entries) {
+			continue
+		}
+
+		if len(kept) == 0 {
+			diags.Append(r.removeAll(ctx, data, asset.PublicID)...)
+		} else {
+			diags.Append(r.replace(ctx, data, kept, asset.PublicID)...)
+		}
+
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// replace replaces the whole context of an asset with the explicit call, so
+// that the entries which are kept are never missing in between.
+func (r assetContextResource) replace(ctx context.Context, data assetContextResourceData, entries map[string]string, publicID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	res, err := r.provider.client.Upload.Explicit(ctx, uploader.ExplicitParams{
+		PublicID:     publicID,
+		Type:         api.DeliveryType(data.Type.Value),
+		ResourceType: data.ResourceType.Value,
+		Context:      escapeContext(entries),
+	})
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to replace context, got error: %s", err),
+		)
+		return diags
+	}
+
+	if res.Error.Message != "" {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to replace context, got error: %s", res.Error.Message),
+		)
+		return diags
+	}
+
+	return diags
+}
+
+// removeAll removes every entry from the context of an asset.
+func (r assetContextResource) removeAll(ctx context.Context, data assetContextResourceData, publicID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	res, err := r.provider.client.Upload.RemoveAllContext(ctx, uploader.RemoveAllContextParams{
+		PublicIDs:    []string{publicID},
+		Type:         data.Type.Value,
+		ResourceType: data.ResourceType.Value,
+	})
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to remove context, got error: %s", err),
+		)
+		return diags
+	}
+
+	if res.Error.Message != "" {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to remove context, got error: %s", res.Error.Message),
+		)
+		return diags
+	}
+
+	return diags
+}
+
+// escapeContext escapes the keys and values of context entries, which are
+// joined with `=` and `|` by the client.
+func escapeContext(entries map[string]string) api.CldAPIMap {
+	escaped := api.CldAPIMap{}
+
+	for key, value := range entries {
+		escaped[contextEscaper.Replace(key)] = contextEscaper.Replace(value)
+	}
+
+	return escaped
+}
+
+// customContext returns the custom entries of the context of an asset.
+func customContext(c api.Metadata) map[string]string {
+	entries := map[string]string{}
+
+	custom, _ := c["custom"].(map[string]interface{})
+	for key, value := range custom {
+		entries[key] = fmt.Sprint(value)
+	}
+
+	return entries
+}
+
+// observedEntries returns the entries to store in the state given the
+// entries of each asset. An entry keeps its managed value when all assets
+// have it, takes the differing value of an asset otherwise, and is left out
+// when only some assets have it.
+func observedEntries(managed map[string]string, assetEntries []map[string]string) map[string]string {
+	entries := map[string]string{}
+
+	for key, value := range managed {
+		observed, everywhere := value, true
+
+		for _, e := range assetEntries {
+			v, ok := e[key]
+			if !ok {
+				everywhere = false
+			} else if v != value {
+				observed = v
+			}
+		}
+
+		if observed != value || everywhere {
+			entries[key] = observed
+		}
+	}
+
+	return entries
+}
+
+// stringMap returns a map of strings.
+func stringMap(values map[string]string) types.Map {
+	m := types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}}
+
+	for k, v := range values {
+		m.Elems[k] = types.String{Value: v}
+	}
+
+	return m
+}
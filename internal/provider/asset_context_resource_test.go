@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAssetContextResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAssetContextResourceConfig(`{ alt = "Red pixel", caption = "a=b|c" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_asset_context.test", "context.alt", "Red pixel"),
+					resource.TestCheckResourceAttr("cloudinary_asset_context.test", "context.caption", "a=b|c"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccAssetContextResourceConfig(`{ alt = "A red pixel" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_asset_context.test", "context.%", "1"),
+					resource.TestCheckResourceAttr("cloudinary_asset_context.test", "context.alt", "A red pixel"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAssetContextResourceConfig(context string) string {
+	return fmt.Sprintf(`
+resource "cloudinary_asset" "test" {
+  content_base64 = %[1]q
+  public_id      = "terraform_acc_test/context"
+}
+
+resource "cloudinary_asset_context" "test" {
+  public_ids = [cloudinary_asset.test.public_id]
+  context    = %[2]s
+}
+`, testAccRedPixel, context)
+}
+
+func TestEscapeContext(t *testing.T) {
+	got := escapeContext(map[string]string{"caption": "a=b|c", "k=v": "x", "path": `C:\`})
+	want := api.CldAPIMap{"caption": `a\=b\|c`, `k\=v`: "x", "path": `C:\\`}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("escapeContext() = %v, want %v", got, want)
+	}
+}
+
+func TestCustomContext(t *testing.T) {
+	got := customContext(api.Metadata{"custom": map[string]interface{}{"alt": "Logo"}})
+	if !reflect.DeepEqual(got, map[string]string{"alt": "Logo"}) {
+		t.Errorf("customContext() = %v", got)
+	}
+
+	if got := customContext(nil); len(got) != 0 {
+		t.Errorf("customContext(nil) = %v", got)
+	}
+}
+
+func TestObservedEntries(t *testing.T) {
+	managed := map[string]string{"alt": "Logo", "caption": "Brand"}
+
+	tests := []struct {
+		assetEntries []map[string]string
+		want         map[string]string
+	}{
+		{
+			[]map[string]string{{"alt": "Logo", "caption": "Brand", "other": "x"}, {"alt": "Logo", "caption": "Brand"}},
+			map[string]string{"alt": "Logo", "caption": "Brand"},
+		},
+		{
+			[]map[string]string{{"alt": "Logo", "caption": "Brand"}, {"alt": "Logo"}},
+			map[string]string{"alt": "Logo"},
+		},
+		{
+			[]map[string]string{{"alt": "Logo", "caption": "Changed"}, {"caption": "Brand"}},
+			map[string]string{"caption": "Changed"},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := observedEntries(managed, tt.assetEntries); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("observedEntries(%v) = %v, want %v", tt.assetEntries, got, tt.want)
+		}
+	}
+}
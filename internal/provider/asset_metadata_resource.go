@@ -0,0 +1,600 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/cloudinary/cloudinary-go/api/admin/metadata"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// metadataEscaper escapes the separators of metadata values.
+var metadataEscaper = strings.NewReplacer(`=`, `\=`, `"`, `\"`, `|`, `\|`)
+
+// maxMetadataPublicIDs is the number of public IDs accepted by a metadata
+// call.
+const maxMetadataPublicIDs = 1000
+
+type assetMetadataResourceType struct{}
+
+func (t assetMetadataResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Asset Metadata resource. Sets structured metadata values of existing assets without managing the assets themselves. Values of fields which are not managed by the resource are kept.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"public_ids": {
+				MarkdownDescription: "The public IDs of the assets.",
+				Required:            true,
+				Type:                types.SetType{ElemType: types.StringType},
+			},
+			"resource_type": {
+				MarkdownDescription: "The resource type of the assets. One of `image` (default), `video` or `raw`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"image", "raw", "video"}},
+				},
+			},
+			"set_values": {
+				MarkdownDescription: "The values of `set` fields by the external ID of the field, given as the external IDs of the selected datasource values.",
+				Optional:            true,
+				Type:                types.MapType{ElemType: types.SetType{ElemType: types.StringType}},
+			},
+			"type": {
+				MarkdownDescription: "The delivery type of the assets. One of `upload` (default), `private` or `authenticated`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"authenticated", "private", "upload"}},
+				},
+			},
+			"values": {
+				MarkdownDescription: "The values of `string`, `integer`, `date` and `enum` fields by the external ID of the field. Integers are given as strings, dates as `YYYY-MM-DD` and `enum` values as the external ID of the datasource value.",
+				Optional:            true,
+				Type:                types.MapType{ElemType: types.StringType},
+			},
+		},
+	}, nil
+}
+
+func (t assetMetadataResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return assetMetadataResource{
+		provider: provider,
+	}, diags
+}
+
+type assetMetadataResourceData struct {
+	ID           types.String `tfsdk:"id"`
+	PublicIDs    types.Set    `tfsdk:"public_ids"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	SetValues    types.Map    `tfsdk:"set_values"`
+	Type         types.String `tfsdk:"type"`
+	Values       types.Map    `tfsdk:"values"`
+}
+
+// setDefaults fills the optional attributes which are not configured with
+// their default values.
+func (data *assetMetadataResourceData) setDefaults() {
+	if data.ResourceType.Null || data.ResourceType.Unknown {
+		data.ResourceType = types.String{Value: "image"}
+	}
+	if data.Type.Null || data.Type.Unknown {
+		data.Type = types.String{Value: "upload"}
+	}
+}
+
+// entries returns the managed values encoded as metadata call values. The
+// values of set fields are JSON arrays.
+func (data assetMetadataResourceData) entries(ctx context.Context) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	entries := map[string]string{}
+
+	if !data.Values.Null && !data.Values.Unknown {
+		diags.Append(data.Values.ElementsAs(ctx, &entries, false)...)
+	}
+
+	if !data.SetValues.Null && !data.SetValues.Unknown {
+		setValues := map[string][]string{}
+		diags.Append(data.SetValues.ElementsAs(ctx, &setValues, false)...)
+
+		for field, ids := range setValues {
+			entries[field] = encodeMetadataSet(ids)
+		}
+	}
+
+	return entries, diags
+}
+
+// setEntries stores entries encoded as metadata call values in the values
+// and set values of the resource. Set values are only stored in set_values
+// when the field is managed there.
+func (data *assetMetadataResourceData) setEntries(entries map[string]string) {
+	values := map[string]string{}
+	setValues := map[string][]string{}
+
+	for field, value := range entries {
+		if _, ok := data.SetValues.Elems[field]; ok {
+			setValues[field] = decodeMetadataSet(value)
+		} else {
+			values[field] = value
+		}
+	}
+
+	if !data.Values.Null || len(values) > 0 {
+		data.Values = stringMap(values)
+	}
+
+	if !data.SetValues.Null || len(setValues) > 0 {
+		m := types.Map{ElemType: types.SetType{ElemType: types.StringType}, Elems: map[string]attr.Value{}}
+		for field, ids := range setValues {
+			m.Elems[field] = stringSet(ids)
+		}
+		data.SetValues = m
+	}
+}
+
+// assetMetadataResult is the result of the metadata call.
+type assetMetadataResult struct {
+	PublicIDs []string      `json:"public_ids"`
+	Error     api.ErrorResp `json:"error,omitempty"`
+}
+
+type assetMetadataResource struct {
+	provider provider
+}
+
+func (r assetMetadataResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data assetMetadataResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Values are validated once they are known.
+	if data.Values.Unknown || data.SetValues.Unknown {
+		return
+	}
+
+	values := map[string]types.String{}
+	if !data.Values.Null {
+		resp.Diagnostics.Append(data.Values.ElementsAs(ctx, &values, false)...)
+	}
+
+	setValues := map[string][]types.String{}
+	if !data.SetValues.Null {
+		resp.Diagnostics.Append(data.SetValues.ElementsAs(ctx, &setValues, true)...)
+	}
+
+	if resp.Diagnostics.HasError() || (len(values) == 0 && len(setValues) == 0) {
+		return
+	}
+
+	res, err := r.provider.client.Admin.ListMetadataFields(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read metadata fields, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read metadata fields, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(validateAssetMetadataValues(res.MetadataFields, values, setValues)...)
+}
+
+func (r assetMetadataResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data assetMetadataResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setDefaults()
+
+	var publicIDs []string
+	resp.Diagnostics.Append(data.PublicIDs.ElementsAs(ctx, &publicIDs, false)...)
+
+	entries, diags := data.entries(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.update(ctx, data, entries, publicIDs)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.String{Value: assetsID(data.ResourceType.Value, data.Type.Value, publicIDs)}
+
+	tflog.Trace(ctx, "created a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetMetadataResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data assetMetadataResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var publicIDs []string
+	resp.Diagnostics.Append(data.PublicIDs.ElementsAs(ctx, &publicIDs, false)...)
+
+	entries, diags := data.entries(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var (
+		found        []string
+		assetEntries []map[string]string
+	)
+
+	// Metadata is only returned by the details of a single asset.
+	for _, publicID := range publicIDs {
+		var res assetDetailsResult
+
+		err := callAdminAPI(ctx, r.provider.client, http.MethodGet, api.BuildPath("resources", data.ResourceType.Value, data.Type.Value, publicID), nil, &res)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to read asset, got error: %s", err),
+			)
+			return
+		}
+
+		if isNotFoundError(res.Error.Message) {
+			continue
+		}
+
+		if res.Error.Message != "" {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to read asset, got error: %s", res.Error.Message),
+			)
+			return
+		}
+
+		found = append(found, res.PublicID)
+		assetEntries = append(assetEntries, metadataEntries(res.Metadata))
+	}
+
+	if len(found) == 0 {
+		tflog.Warn(ctx, "assets not found, removing the metadata from the state", map[string]interface{}{
+			"id": data.ID.Value,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.PublicIDs = stringSet(found)
+	data.setEntries(observedEntries(entries, assetEntries))
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetMetadataResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, state assetMetadataResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setDefaults()
+
+	var publicIDs, currentPublicIDs []string
+	resp.Diagnostics.Append(data.PublicIDs.ElementsAs(ctx, &publicIDs, false)...)
+	resp.Diagnostics.Append(state.PublicIDs.ElementsAs(ctx, &currentPublicIDs, false)...)
+
+	entries, diags := data.entries(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	currentEntries, diags := state.entries(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Assets which are no longer managed lose the values of the resource.
+	resp.Diagnostics.Append(r.update(ctx, data, clearedEntries(currentEntries, nil), difference(currentPublicIDs, publicIDs))...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.update(ctx, data, clearedEntries(currentEntries, entries), publicIDs)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.String{Value: assetsID(data.ResourceType.Value, data.Type.Value, publicIDs)}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetMetadataResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data assetMetadataResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var publicIDs []string
+	resp.Diagnostics.Append(data.PublicIDs.ElementsAs(ctx, &publicIDs, false)...)
+
+	entries, diags := data.entries(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.update(ctx, data, clearedEntries(entries, nil), publicIDs)...)
+}
+
+// update sets the metadata values of the assets. Fields which are not given
+// keep their values, and empty values clear the field.
+func (r assetMetadataResource) update(ctx context.Context, data assetMetadataResourceData, entries map[string]string, publicIDs []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if len(entries) == 0 || len(publicIDs) == 0 {
+		return diags
+	}
+
+	fields := make([]string, 0, len(entries))
+	for field := range entries {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	pairs := make([]string, 0, len(entries))
+	for _, field := range fields {
+		value := entries[field]
+		if _, ok := data.SetValues.Elems[field]; !ok || value == "" {
+			value = metadataEscaper.Replace(value)
+		}
+		pairs = append(pairs, field+"="+value)
+	}
+
+	for _, batch := range batchStrings(publicIDs, maxMetadataPublicIDs) {
+		params := url.Values{}
+		params.Set("metadata", strings.Join(pairs, "|"))
+		params.Set("type", data.Type.Value)
+		for _, publicID := range batch {
+			params.Add("public_ids[]", publicID)
+		}
+
+		var res assetMetadataResult
+
+		err := callUploadAPI(ctx, r.provider.client, api.BuildPath(data.ResourceType.Value, "metadata"), params, &res)
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to update metadata, got error: %s", err),
+			)
+			return diags
+		}
+
+		if res.Error.Message != "" {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to update metadata, got error: %s", res.Error.Message),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// validateAssetMetadataValues validates metadata values against the types
+// and datasources of the fields. Fields which do not exist yet may be
+// created in the same apply, so they are left to the API.
+func validateAssetMetadataValues(fields []metadata.Field, values map[string]types.String, setValues map[string][]types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	byID := map[string]metadata.Field{}
+	for _, f := range fields {
+		byID[f.ExternalID] = f
+	}
+
+	datasourceIDs := func(f metadata.Field) []string {
+		var ids []string
+		for _, v := range f.DataSource.Values {
+			if v.State != "inactive" {
+				ids = append(ids, v.ExternalID)
+			}
+		}
+		return ids
+	}
+
+	for id, value := range values {
+		p := path.Root("values").AtMapKey(id)
+
+		f, ok := byID[id]
+		if !ok {
+			diags.AddAttributeWarning(p, "Unknown Metadata Field", fmt.Sprintf("The metadata field %q does not exist yet, its values are validated on apply.", id))
+			continue
+		}
+
+		if value.Null || value.Unknown || value.Value == "" {
+			continue
+		}
+
+		switch f.Type {
+		case metadata.SetFieldType:
+			diags.AddAttributeError(p, "Invalid Metadata Field", fmt.Sprintf("The metadata field %q is a set field, use set_values instead.", id))
+		case metadata.IntegerFieldType:
+			if _, err := strconv.ParseInt(value.Value, 10, 64); err != nil {
+				diags.AddAttributeError(p, "Invalid Metadata Value", fmt.Sprintf("The value %q of the integer field %q must be an integer.", value.Value, id))
+			}
+		case metadata.DateFieldType:
+			if _, err := time.Parse("2006-01-02", value.Value); err != nil {
+				diags.AddAttributeError(p, "Invalid Metadata Value", fmt.Sprintf("The value %q of the date field %q must be a YYYY-MM-DD date.", value.Value, id))
+			}
+		case metadata.EnumFieldType:
+			if !containsString(datasourceIDs(f), value.Value) {
+				diags.AddAttributeError(p, "Invalid Metadata Value", fmt.Sprintf("The value %q of the enum field %q must be the external ID of an active datasource value.", value.Value, id))
+			}
+		}
+	}
+
+	for id, ids := range setValues {
+		p := path.Root("set_values").AtMapKey(id)
+
+		f, ok := byID[id]
+		if !ok {
+			diags.AddAttributeWarning(p, "Unknown Metadata Field", fmt.Sprintf("The metadata field %q does not exist yet, its values are validated on apply.", id))
+			continue
+		}
+
+		if f.Type != metadata.SetFieldType {
+			diags.AddAttributeError(p, "Invalid Metadata Field", fmt.Sprintf("The metadata field %q is a %s field, use values instead.", id, f.Type))
+			continue
+		}
+
+		for _, v := range ids {
+			if v.Null || v.Unknown {
+				continue
+			}
+
+			if !containsString(datasourceIDs(f), v.Value) {
+				diags.AddAttributeError(p, "Invalid Metadata Value", fmt.Sprintf("The value %q of the set field %q must be the external ID of an active datasource value.", v.Value, id))
+			}
+		}
+	}
+
+	return diags
+}
+
+// metadataEntries returns the metadata values of an asset encoded as
+// metadata call values.
+func metadataEntries(m api.Metadata) map[string]string {
+	entries := map[string]string{}
+
+	for field, value := range m {
+		switch v := value.(type) {
+		case []interface{}:
+			ids := make([]string, 0, len(v))
+			for _, e := range v {
+				ids = append(ids, fmt.Sprint(e))
+			}
+			entries[field] = encodeMetadataSet(ids)
+		default:
+			entries[field] = flattenValue(v)
+		}
+	}
+
+	return entries
+}
+
+// clearedEntries returns empty values, which clear the fields, for the
+// fields of current which are not in entries, along with entries.
+func clearedEntries(current map[string]string, entries map[string]string) map[string]string {
+	cleared := map[string]string{}
+
+	for field := range current {
+		if _, ok := entries[field]; !ok {
+			cleared[field] = ""
+		}
+	}
+
+	for field, value := range entries {
+		cleared[field] = value
+	}
+
+	return cleared
+}
+
+// encodeMetadataSet encodes the value of a set field as a JSON array, sorted
+// so that equal sets are encoded equally.
+func encodeMetadataSet(ids []string) string {
+	sorted := append([]string{}, ids...)
+	sort.Strings(sorted)
+
+	b, _ := json.Marshal(sorted)
+
+	return string(b)
+}
+
+func decodeMetadataSet(s string) []string {
+	var ids []string
+	_ = json.Unmarshal([]byte(s), &ids)
+
+	return ids
+}
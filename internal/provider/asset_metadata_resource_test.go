@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/cloudinary/cloudinary-go/api/admin/metadata"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAssetMetadataResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAssetMetadataResourceConfig(`"2030-01-31"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_asset_metadata.test", "values.terraform_acc_test_expiry", "2030-01-31"),
+					resource.TestCheckResourceAttr("cloudinary_asset_metadata.test", "set_values.terraform_acc_test_channels.#", "2"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccAssetMetadataResourceConfig(`"2031-12-31"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_asset_metadata.test", "values.terraform_acc_test_expiry", "2031-12-31"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAssetMetadataResourceConfig(expiry string) string {
+	return fmt.Sprintf(`
+resource "cloudinary_asset" "test" {
+  content_base64 = %[1]q
+  public_id      = "terraform_acc_test/metadata"
+}
+
+resource "cloudinary_metadata_field" "expiry" {
+  external_id = "terraform_acc_test_expiry"
+  type        = "date"
+  label       = "Terraform Acceptance Test Expiry"
+}
+
+resource "cloudinary_metadata_field" "channels" {
+  external_id = "terraform_acc_test_channels"
+  type        = "set"
+  label       = "Terraform Acceptance Test Channels"
+
+  datasource {
+    value {
+      external_id = "web"
+      value       = "Web"
+    }
+
+    value {
+      external_id = "print"
+      value       = "Print"
+    }
+  }
+}
+
+resource "cloudinary_asset_metadata" "test" {
+  public_ids = [cloudinary_asset.test.public_id]
+
+  values = {
+    terraform_acc_test_expiry = %[2]s
+  }
+
+  set_values = {
+    terraform_acc_test_channels = ["web", "print"]
+  }
+
+  depends_on = [
+    cloudinary_metadata_field.expiry,
+    cloudinary_metadata_field.channels,
+  ]
+}
+`, testAccRedPixel, expiry)
+}
+
+func TestValidateAssetMetadataValues(t *testing.T) {
+	fields := []metadata.Field{
+		{ExternalID: "name", Type: metadata.StringFieldType},
+		{ExternalID: "count", Type: metadata.IntegerFieldType},
+		{ExternalID: "expiry", Type: metadata.DateFieldType},
+		{
+			ExternalID: "color",
+			Type:       metadata.EnumFieldType,
+			DataSource: metadata.DataSource{Values: []metadata.DataSourceValue{
+				{ExternalID: "red", State: "active"},
+				{ExternalID: "blue", State: "inactive"},
+			}},
+		},
+		{
+			ExternalID: "channels",
+			Type:       metadata.SetFieldType,
+			DataSource: metadata.DataSource{Values: []metadata.DataSourceValue{
+				{ExternalID: "web", State: "active"},
+			}},
+		},
+	}
+
+	tests := []struct {
+		values    map[string]types.String
+		setValues map[string][]types.String
+		errors    int
+		warnings  int
+	}{
+		{
+			map[string]types.String{
+				"name":   {Value: "a=b"},
+				"count":  {Value: "42"},
+				"expiry": {Value: "2030-01-31"},
+				"color":  {Value: "red"},
+			},
+			map[string][]types.String{"channels": {{Value: "web"}}},
+			0, 0,
+		},
+		{
+			map[string]types.String{
+				"count":    {Value: "4.2"},
+				"expiry":   {Value: "31/01/2030"},
+				"color":    {Value: "blue"},
+				"channels": {Value: "web"},
+			},
+			map[string][]types.String{"channels": {{Value: "print"}}, "name": {{Value: "x"}}},
+			6, 0,
+		},
+		{
+			map[string]types.String{"missing": {Value: "x"}, "count": {Unknown: true}},
+			map[string][]types.String{"missing": {{Value: "x"}}},
+			0, 2,
+		},
+	}
+
+	for i, tt := range tests {
+		diags := validateAssetMetadataValues(fields, tt.values, tt.setValues)
+
+		if got := diags.ErrorsCount(); got != tt.errors {
+			t.Errorf("%d: validateAssetMetadataValues() errors = %d, want %d: %v", i, got, tt.errors, diags)
+		}
+
+		if got := diags.WarningsCount(); got != tt.warnings {
+			t.Errorf("%d: validateAssetMetadataValues() warnings = %d, want %d: %v", i, got, tt.warnings, diags)
+		}
+	}
+}
+
+func TestMetadataEntries(t *testing.T) {
+	got := metadataEntries(api.Metadata{
+		"count":    float64(42),
+		"expiry":   "2030-01-31",
+		"channels": []interface{}{"web", "print"},
+	})
+	want := map[string]string{
+		"count":    "42",
+		"expiry":   "2030-01-31",
+		"channels": `["print","web"]`,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("metadataEntries() = %v, want %v", got, want)
+	}
+}
+
+func TestClearedEntries(t *testing.T) {
+	got := clearedEntries(map[string]string{"a": "1", "b": "2"}, map[string]string{"b": "3", "c": "4"})
+	want := map[string]string{"a": "", "b": "3", "c": "4"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("clearedEntries() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,307 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// manualModerationKind is the moderation kind of assets moderated in the
+// Media Library.
+const manualModerationKind = "manual"
+
+// moderationStatuses are the statuses of an asset in a moderation queue.
+var moderationStatuses = []string{string(api.Approved), string(api.Pending), string(api.Rejected)}
+
+type assetModerationResourceType struct{}
+
+func (t assetModerationResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Asset Moderation resource. Sets the moderation status of an existing asset. Destroying the resource keeps the status of the asset.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "The ID of the asset in the `resource_type/type/public_id` form.",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"kind": {
+				MarkdownDescription: "The moderation kind, e.g. `manual` (default), `webpurify` or `aws_rek`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"public_id": {
+				MarkdownDescription: "The public ID of the asset.",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"resource_type": {
+				MarkdownDescription: "The resource type of the asset. One of `image` (default), `video` or `raw`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"image", "raw", "video"}},
+				},
+			},
+			"status": {
+				MarkdownDescription: "The moderation status of the asset. One of `approved`, `rejected` or `pending`.",
+				Required:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: moderationStatuses},
+				},
+			},
+			"type": {
+				MarkdownDescription: "The delivery type of the asset. One of `upload` (default), `private` or `authenticated`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"authenticated", "private", "upload"}},
+				},
+			},
+		},
+	}, nil
+}
+
+func (t assetModerationResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return assetModerationResource{
+		provider: provider,
+	}, diags
+}
+
+type assetModerationResourceData struct {
+	ID           types.String `tfsdk:"id"`
+	Kind         types.String `tfsdk:"kind"`
+	PublicID     types.String `tfsdk:"public_id"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	Status       types.String `tfsdk:"status"`
+	Type         types.String `tfsdk:"type"`
+}
+
+// setDefaults fills the optional attributes which are not configured with
+// their default values.
+func (data *assetModerationResourceData) setDefaults() {
+	if data.Kind.Null || data.Kind.Unknown {
+		data.Kind = types.String{Value: manualModerationKind}
+	}
+	if data.ResourceType.Null || data.ResourceType.Unknown {
+		data.ResourceType = types.String{Value: "image"}
+	}
+	if data.Type.Null || data.Type.Unknown {
+		data.Type = types.String{Value: "upload"}
+	}
+}
+
+// assetModerationParams are the parameters of the update asset call which
+// set the moderation of the asset.
+type assetModerationParams struct {
+	Moderation       string `json:"moderation"`
+	ModerationStatus string `json:"moderation_status"`
+}
+
+// assetModerationResult is the result of the asset details and update asset
+// calls, including the moderations of the asset.
+type assetModerationResult struct {
+	PublicID   string `json:"public_id"`
+	Moderation []struct {
+		Kind   string `json:"kind"`
+		Status string `json:"status"`
+	} `json:"moderation"`
+	Error api.ErrorResp `json:"error,omitempty"`
+}
+
+// status returns the moderation status of the asset for the kind, if the
+// asset is moderated with it.
+func (res assetModerationResult) status(kind string) (string, bool) {
+	for _, m := range res.Moderation {
+		if m.Kind == kind {
+			return m.Status, true
+		}
+	}
+
+	return "", false
+}
+
+type assetModerationResource struct {
+	provider provider
+}
+
+func (r assetModerationResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data assetModerationResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setDefaults()
+
+	resp.Diagnostics.Append(r.moderate(ctx, data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.String{Value: assetID(data.ResourceType.Value, data.Type.Value, data.PublicID.Value)}
+
+	tflog.Trace(ctx, "created a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetModerationResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data assetModerationResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var res assetModerationResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodGet, api.BuildPath("resources", data.ResourceType.Value, data.Type.Value, data.PublicID.Value), nil, &res)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read asset, got error: %s", err),
+		)
+		return
+	}
+
+	if isNotFoundError(res.Error.Message) {
+		tflog.Warn(ctx, "asset not found, removing the moderation from the state", map[string]interface{}{
+			"id": data.ID.Value,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read asset, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	// An asset which is no longer moderated with the kind is moderated again.
+	status, ok := res.status(data.Kind.Value)
+	if !ok {
+		tflog.Warn(ctx, "asset not moderated, removing the moderation from the state", map[string]interface{}{
+			"id":   data.ID.Value,
+			"kind": data.Kind.Value,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Status = types.String{Value: status}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetModerationResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data assetModerationResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setDefaults()
+
+	resp.Diagnostics.Append(r.moderate(ctx, data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetModerationResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data assetModerationResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Moderation cannot be removed from an asset, so the status is kept.
+	tflog.Debug(ctx, "keeping the moderation status of the asset", map[string]interface{}{
+		"id":     data.ID.Value,
+		"status": data.Status.Value,
+	})
+}
+
+// moderate sets the moderation status of the asset for the kind.
+func (r assetModerationResource) moderate(ctx context.Context, data assetModerationResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	params := assetModerationParams{
+		Moderation:       data.Kind.Value,
+		ModerationStatus: data.Status.Value,
+	}
+
+	var res assetModerationResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodPost, api.BuildPath("resources", data.ResourceType.Value, data.Type.Value, data.PublicID.Value), params, &res)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update moderation, got error: %s", err),
+		)
+		return diags
+	}
+
+	if res.Error.Message != "" {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update moderation, got error: %s", res.Error.Message),
+		)
+		return diags
+	}
+
+	return diags
+}
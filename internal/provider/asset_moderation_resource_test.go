@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAssetModerationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAssetModerationResourceConfig("approved"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_asset_moderation.test", "id", "image/upload/terraform_acc_test/moderation"),
+					resource.TestCheckResourceAttr("cloudinary_asset_moderation.test", "kind", "manual"),
+					resource.TestCheckResourceAttr("cloudinary_asset_moderation.test", "status", "approved"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccAssetModerationResourceConfig("rejected"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_asset_moderation.test", "status", "rejected"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAssetModerationResourceConfig(status string) string {
+	return fmt.Sprintf(`
+resource "cloudinary_asset" "test" {
+  content_base64 = %[1]q
+  public_id      = "terraform_acc_test/moderation"
+}
+
+resource "cloudinary_asset_moderation" "test" {
+  public_id = cloudinary_asset.test.public_id
+  status    = %[2]q
+}
+`, testAccRedPixel, status)
+}
+
+func TestAssetModerationResultStatus(t *testing.T) {
+	var res assetModerationResult
+	if err := json.Unmarshal([]byte(`{"moderation":[{"kind":"webpurify","status":"approved"},{"kind":"manual","status":"rejected"}]}`), &res); err != nil {
+		t.Fatal(err)
+	}
+
+	if status, ok := res.status("manual"); !ok || status != "rejected" {
+		t.Errorf("status(manual) = %q, %t", status, ok)
+	}
+
+	if _, ok := res.status("aws_rek"); ok {
+		t.Error("status(aws_rek) found")
+	}
+}
@@ -0,0 +1,471 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxRelatedAssets is the number of assets accepted by a related assets
+// call.
+const maxRelatedAssets = 10
+
+type assetRelationResourceType struct{}
+
+func (t assetRelationResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Asset Relation resource. Manages all related assets of an existing asset. Related assets which are not managed by the resource are removed.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"asset_id": {
+				MarkdownDescription: "The asset ID of the source asset.",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"id": {
+				MarkdownDescription: "The asset ID of the source asset.",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"related_asset_ids": {
+				MarkdownDescription: "The asset IDs of the related assets.",
+				Optional:            true,
+				Type:                types.SetType{ElemType: types.StringType},
+			},
+			"related_assets": {
+				MarkdownDescription: "The related assets in the `resource_type/type/public_id` form, which is the `id` of `cloudinary_asset`.",
+				Optional:            true,
+				Type:                types.SetType{ElemType: types.StringType},
+			},
+		},
+	}, nil
+}
+
+func (t assetRelationResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return assetRelationResource{
+		provider: provider,
+	}, diags
+}
+
+type assetRelationResourceData struct {
+	AssetID         types.String `tfsdk:"asset_id"`
+	ID              types.String `tfsdk:"id"`
+	RelatedAssetIDs types.Set    `tfsdk:"related_asset_ids"`
+	RelatedAssets   types.Set    `tfsdk:"related_assets"`
+}
+
+// related returns the related assets given by asset ID and by public ID.
+func (data assetRelationResourceData) related(ctx context.Context) ([]string, []string, diag.Diagnostics) {
+	var (
+		diags    diag.Diagnostics
+		assetIDs []string
+		assets   []string
+	)
+
+	if !data.RelatedAssetIDs.Null && !data.RelatedAssetIDs.Unknown {
+		diags.Append(data.RelatedAssetIDs.ElementsAs(ctx, &assetIDs, false)...)
+	}
+
+	if !data.RelatedAssets.Null && !data.RelatedAssets.Unknown {
+		diags.Append(data.RelatedAssets.ElementsAs(ctx, &assets, false)...)
+	}
+
+	return assetIDs, assets, diags
+}
+
+// relatedAsset is a related asset returned by the asset details call.
+type relatedAsset struct {
+	AssetID      string `json:"asset_id"`
+	PublicID     string `json:"public_id"`
+	ResourceType string `json:"resource_type"`
+	Type         string `json:"type"`
+}
+
+// relatedAssetsParams are the parameters of the asset details call which
+// include the related assets.
+type relatedAssetsParams struct {
+	Related bool `json:"related"`
+}
+
+// relatedAssetsResult is the result of the asset details call including the
+// related assets.
+type relatedAssetsResult struct {
+	AssetID       string         `json:"asset_id"`
+	PublicID      string         `json:"public_id"`
+	ResourceType  string         `json:"resource_type"`
+	Type          string         `json:"type"`
+	RelatedAssets []relatedAsset `json:"related_assets"`
+	Error         api.ErrorResp  `json:"error,omitempty"`
+}
+
+// relateAssetsParams are the parameters of the relate and unrelate calls.
+type relateAssetsParams struct {
+	AssetsToRelate   []string `json:"assets_to_relate,omitempty"`
+	AssetsToUnrelate []string `json:"assets_to_unrelate,omitempty"`
+}
+
+// relateAssetsResult is the result of the relate and unrelate calls.
+type relateAssetsResult struct {
+	Failed []struct {
+		Asset   string `json:"asset"`
+		Message string `json:"message"`
+	} `json:"failed"`
+	Error api.ErrorResp `json:"error,omitempty"`
+}
+
+type assetRelationResource struct {
+	provider provider
+}
+
+func (r assetRelationResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data assetRelationResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assetIDs, assets, diags := data.related(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resolved, diags := r.resolve(ctx, assets, false)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var res relatedAssetsResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodGet, api.BuildPath("resources", data.AssetID.Value), relatedAssetsParams{Related: true}, &res)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read asset, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read asset, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	// The relations are authoritative, so the assets already related to the
+	// source asset which are not configured are unrelated.
+	var current []string
+	for _, a := range res.RelatedAssets {
+		current = append(current, a.AssetID)
+	}
+
+	configured := append(assetIDs, resolved...)
+
+	resp.Diagnostics.Append(r.relate(ctx, data.AssetID.Value, http.MethodDelete, difference(current, configured))...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.relate(ctx, data.AssetID.Value, http.MethodPost, difference(configured, current))...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.AssetID
+
+	tflog.Trace(ctx, "created a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetRelationResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data assetRelationResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assetIDs, assets, diags := data.related(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var res relatedAssetsResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodGet, api.BuildPath("resources", data.AssetID.Value), relatedAssetsParams{Related: true}, &res)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read asset, got error: %s", err),
+		)
+		return
+	}
+
+	if isNotFoundError(res.Error.Message) {
+		tflog.Warn(ctx, "asset not found, removing the relation from the state", map[string]interface{}{
+			"id": data.ID.Value,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read asset, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	observedIDs, observedAssets := observedRelatedAssets(assetIDs, assets, !data.RelatedAssets.Null && data.RelatedAssetIDs.Null, res.RelatedAssets)
+
+	data.ID = data.AssetID
+	if !data.RelatedAssetIDs.Null || len(observedIDs) > 0 {
+		data.RelatedAssetIDs = stringSet(observedIDs)
+	}
+	if !data.RelatedAssets.Null || len(observedAssets) > 0 {
+		data.RelatedAssets = stringSet(observedAssets)
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetRelationResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, state assetRelationResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assetIDs, assets, diags := data.related(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	currentAssetIDs, currentAssets, diags := state.related(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Assets which no longer exist have nothing to unrelate.
+	removed, diags := r.resolve(ctx, difference(currentAssets, assets), true)
+	resp.Diagnostics.Append(diags...)
+
+	added, diags := r.resolve(ctx, difference(assets, currentAssets), false)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.relate(ctx, data.AssetID.Value, http.MethodDelete, append(difference(currentAssetIDs, assetIDs), removed...))...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.relate(ctx, data.AssetID.Value, http.MethodPost, append(difference(assetIDs, currentAssetIDs), added...))...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.AssetID
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetRelationResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data assetRelationResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assetIDs, assets, diags := data.related(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resolved, diags := r.resolve(ctx, assets, true)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.relate(ctx, data.AssetID.Value, http.MethodDelete, append(assetIDs, resolved...))...)
+}
+
+func (r assetRelationResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, path.Root("asset_id"), req, resp)
+}
+
+// resolve returns the asset IDs of assets given in the
+// resource_type/type/public_id form. When skipMissing is set, assets which do
+// not exist are left out instead of failing.
+func (r assetRelationResource) resolve(ctx context.Context, assets []string, skipMissing bool) ([]string, diag.Diagnostics) {
+	var (
+		diags    diag.Diagnostics
+		assetIDs []string
+	)
+
+	for _, asset := range assets {
+		parts := strings.SplitN(asset, "/", 3)
+		if len(parts) != 3 {
+			diags.AddAttributeError(
+				path.Root("related_assets"),
+				"Invalid Attribute Value",
+				fmt.Sprintf("Expected a related asset with format: resource_type/type/public_id. Got: %q", asset),
+			)
+			return nil, diags
+		}
+
+		var res relatedAssetsResult
+
+		err := callAdminAPI(ctx, r.provider.client, http.MethodGet, api.BuildPath("resources", parts[0], parts[1], parts[2]), nil, &res)
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to read related asset, got error: %s", err),
+			)
+			return nil, diags
+		}
+
+		if skipMissing && isNotFoundError(res.Error.Message) {
+			continue
+		}
+
+		if res.Error.Message != "" {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to read related asset %s, got error: %s", asset, res.Error.Message),
+			)
+			return nil, diags
+		}
+
+		assetIDs = append(assetIDs, res.AssetID)
+	}
+
+	return assetIDs, diags
+}
+
+// relate relates the assets to the source asset with the POST method and
+// unrelates them with the DELETE method.
+func (r assetRelationResource) relate(ctx context.Context, assetID string, method string, assetIDs []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	action := "relate"
+	if method == http.MethodDelete {
+		action = "unrelate"
+	}
+
+	for _, batch := range batchStrings(assetIDs, maxRelatedAssets) {
+		params := relateAssetsParams{AssetsToRelate: batch}
+		if method == http.MethodDelete {
+			params = relateAssetsParams{AssetsToUnrelate: batch}
+		}
+
+		var res relateAssetsResult
+
+		err := callAdminAPI(ctx, r.provider.client, method, api.BuildPath("resources", "related_assets", assetID), params, &res)
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to %s assets, got error: %s", action, err),
+			)
+			return diags
+		}
+
+		if res.Error.Message != "" {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to %s assets, got error: %s", action, res.Error.Message),
+			)
+			return diags
+		}
+
+		for _, f := range res.Failed {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to %s asset %s, got error: %s", action, f.Asset, f.Message),
+			)
+		}
+
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// observedRelatedAssets returns the related assets to store in the state,
+// given by asset ID when they are managed by asset ID and by public ID when
+// they are managed by public ID. Related assets which are not managed are
+// given by asset ID, or by public ID when byPublicID is set, so that they are
+// unrelated again.
+func observedRelatedAssets(assetIDs []string, assets []string, byPublicID bool, related []relatedAsset) ([]string, []string) {
+	var observedIDs, observedAssets []string
+
+	for _, a := range related {
+		id := assetID(a.ResourceType, a.Type, a.PublicID)
+
+		switch {
+		case containsString(assetIDs, a.AssetID):
+			observedIDs = append(observedIDs, a.AssetID)
+		case containsString(assets, id) || byPublicID:
+			observedAssets = append(observedAssets, id)
+		default:
+			observedIDs = append(observedIDs, a.AssetID)
+		}
+	}
+
+	return observedIDs, observedAssets
+}
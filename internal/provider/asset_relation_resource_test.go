@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAssetRelationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAssetRelationResourceConfig(`[cloudinary_asset.angle.id, cloudinary_asset.spec.id]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("cloudinary_asset_relation.test", "id", "cloudinary_asset.hero", "asset_id"),
+					resource.TestCheckResourceAttr("cloudinary_asset_relation.test", "related_assets.#", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "cloudinary_asset_relation.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Imported related assets are given by asset ID.
+				ImportStateVerifyIgnore: []string{"related_assets", "related_asset_ids"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccAssetRelationResourceConfig(`[cloudinary_asset.spec.id]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_asset_relation.test", "related_assets.#", "1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAssetRelationResourceConfig(related string) string {
+	return fmt.Sprintf(`
+resource "cloudinary_asset" "hero" {
+  content_base64 = %[1]q
+  public_id      = "terraform_acc_test/relation/hero"
+}
+
+resource "cloudinary_asset" "angle" {
+  content_base64 = %[2]q
+  public_id      = "terraform_acc_test/relation/angle"
+}
+
+resource "cloudinary_asset" "spec" {
+  content_base64 = %[1]q
+  public_id      = "terraform_acc_test/relation/spec"
+  resource_type  = "raw"
+}
+
+resource "cloudinary_asset_relation" "test" {
+  asset_id       = cloudinary_asset.hero.asset_id
+  related_assets = %[3]s
+}
+`, testAccRedPixel, testAccBluePixel, related)
+}
+
+func TestObservedRelatedAssets(t *testing.T) {
+	related := []relatedAsset{
+		{AssetID: "a1", ResourceType: "image", Type: "upload", PublicID: "angle"},
+		{AssetID: "a2", ResourceType: "raw", Type: "upload", PublicID: "spec.pdf"},
+		{AssetID: "a3", ResourceType: "image", Type: "upload", PublicID: "other"},
+	}
+
+	tests := []struct {
+		assetIDs   []string
+		assets     []string
+		byPublicID bool
+		wantIDs    []string
+		wantAssets []string
+	}{
+		{[]string{"a1"}, []string{"raw/upload/spec.pdf"}, false, []string{"a1", "a3"}, []string{"raw/upload/spec.pdf"}},
+		{nil, []string{"image/upload/angle"}, true, nil, []string{"image/upload/angle", "raw/upload/spec.pdf", "image/upload/other"}},
+		{nil, nil, false, []string{"a1", "a2", "a3"}, nil},
+	}
+
+	for _, tt := range tests {
+		gotIDs, gotAssets := observedRelatedAssets(tt.assetIDs, tt.assets, tt.byPublicID, related)
+
+		if !reflect.DeepEqual(gotIDs, tt.wantIDs) || !reflect.DeepEqual(gotAssets, tt.wantAssets) {
+			t.Errorf("observedRelatedAssets(%v, %v, %t) = %v, %v, want %v, %v", tt.assetIDs, tt.assets, tt.byPublicID, gotIDs, gotAssets, tt.wantIDs, tt.wantAssets)
+		}
+	}
+}
@@ -0,0 +1,864 @@
+package provider
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/cloudinary/cloudinary-go/api/admin"
+	"github.com/cloudinary/cloudinary-go/api/uploader"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type assetResourceType struct{}
+
+func (t assetResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Asset resource. Exactly one of `source`, `source_url` or `content_base64` must be set. The asset is uploaded again when the MD5 hash of a local file or inline content no longer matches its `etag`, and renamed in place when its `public_id` changes.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"asset_folder": {
+				MarkdownDescription: "The folder of the asset in dynamic folder mode. Changing it moves the asset without changing its `public_id`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"asset_id": {
+				MarkdownDescription: "The immutable ID of the asset, which is kept when the asset is renamed or uploaded again.",
+				Computed:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"bytes": {
+				MarkdownDescription: "The size of the asset in bytes.",
+				Computed:            true,
+				Type:                types.Int64Type,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"content_base64": {
+				MarkdownDescription: "The base64 encoded content of the asset.",
+				Optional:            true,
+				Sensitive:           true,
+				Type:                types.StringType,
+			},
+			"display_name": {
+				MarkdownDescription: "The name of the asset shown in the Media Library in dynamic folder mode.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"etag": {
+				MarkdownDescription: "The MD5 hash of the uploaded file.",
+				Computed:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"height": {
+				MarkdownDescription: "The height of the asset in pixels.",
+				Computed:            true,
+				Type:                types.Int64Type,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"id": {
+				MarkdownDescription: "The ID of the asset in the `resource_type/type/public_id` form.",
+				Computed:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"invalidate": {
+				MarkdownDescription: "Whether to invalidate the cached copies of the asset on the CDN when it is uploaded again, renamed or deleted. Defaults to `true`.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"overwrite": {
+				MarkdownDescription: "Whether to overwrite an existing asset with the new public ID when the asset is renamed.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"public_id": {
+				MarkdownDescription: "The public ID of the asset, including its folder in fixed folder mode. Generated when omitted. Changing it renames the asset, which keeps its versions.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"resource_type": {
+				MarkdownDescription: "The resource type of the asset. One of `image` (default), `video` or `raw`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"image", "raw", "video"}},
+				},
+			},
+			"secure_url": {
+				MarkdownDescription: "The HTTPS delivery URL of the asset.",
+				Computed:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"source": {
+				MarkdownDescription: "The path of a local file to upload.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"source_url": {
+				MarkdownDescription: "The URL of a remote file to upload.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"type": {
+				MarkdownDescription: "The delivery type of the asset. One of `upload` (default), `private` or `authenticated`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"authenticated", "private", "upload"}},
+				},
+			},
+			"use_asset_folder_as_public_id_prefix": {
+				MarkdownDescription: "Whether to prefix a generated `public_id` with the `asset_folder` in dynamic folder mode. Only applies when the asset is uploaded.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"version": {
+				MarkdownDescription: "The version of the asset, which changes every time it is uploaded.",
+				Computed:            true,
+				Type:                types.Int64Type,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"width": {
+				MarkdownDescription: "The width of the asset in pixels.",
+				Computed:            true,
+				Type:                types.Int64Type,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]tfsdk.Block{
+			"access_control": accessControlBlock("The access types of the asset. Without any, the asset can be accessed according to its delivery type."),
+		},
+	}, nil
+}
+
+func (t assetResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return assetResource{
+		provider: provider,
+	}, diags
+}
+
+type assetResourceData struct {
+	AccessControl                  []accessControlData `tfsdk:"access_control"`
+	AssetFolder                    types.String        `tfsdk:"asset_folder"`
+	AssetID                        types.String        `tfsdk:"asset_id"`
+	Bytes                          types.Int64         `tfsdk:"bytes"`
+	ContentBase64                  types.String        `tfsdk:"content_base64"`
+	DisplayName                    types.String        `tfsdk:"display_name"`
+	Etag                           types.String        `tfsdk:"etag"`
+	Height                         types.Int64         `tfsdk:"height"`
+	ID                             types.String        `tfsdk:"id"`
+	Invalidate                     types.Bool          `tfsdk:"invalidate"`
+	Overwrite                      types.Bool          `tfsdk:"overwrite"`
+	PublicID                       types.String        `tfsdk:"public_id"`
+	ResourceType                   types.String        `tfsdk:"resource_type"`
+	SecureURL                      types.String        `tfsdk:"secure_url"`
+	Source                         types.String        `tfsdk:"source"`
+	SourceURL                      types.String        `tfsdk:"source_url"`
+	Type                           types.String        `tfsdk:"type"`
+	UseAssetFolderAsPublicIDPrefix types.Bool          `tfsdk:"use_asset_folder_as_public_id_prefix"`
+	Version                        types.Int64         `tfsdk:"version"`
+	Width                          types.Int64         `tfsdk:"width"`
+}
+
+// assetResult is the result of the upload and asset details calls,
+// including the attributes of dynamic folder mode.
+type assetResult struct {
+	uploader.UploadResult
+	AccessControl interface{} `json:"access_control"`
+	AssetFolder   string      `json:"asset_folder"`
+	DisplayName   string      `json:"display_name"`
+	Existing      bool        `json:"existing"`
+}
+
+// assetDetailsResult is the result of the asset details call, including the
+// attributes of dynamic folder mode and the access control.
+type assetDetailsResult struct {
+	admin.AssetResult
+	AccessControl interface{} `json:"access_control"`
+	AssetFolder   string      `json:"asset_folder"`
+	DisplayName   string      `json:"display_name"`
+}
+
+// file returns the file to upload.
+func (data assetResourceData) file() (assetFile, error) {
+	switch {
+	case !data.Source.Null:
+		return assetFile{Path: data.Source.Value}, nil
+	case !data.SourceURL.Null:
+		return assetFile{URL: data.SourceURL.Value}, nil
+	default:
+		content, err := base64.StdEncoding.DecodeString(data.ContentBase64.Value)
+		if err != nil {
+			return assetFile{}, fmt.Errorf("invalid content_base64: %w", err)
+		}
+		return assetFile{Content: content}, nil
+	}
+}
+
+// refresh updates the computed attributes with an uploaded asset. The
+// access control is only refreshed when it is returned.
+func (data *assetResourceData) refresh(res assetResult) {
+	if res.AccessControl != nil {
+		data.AccessControl = flattenAccessControl(data.AccessControl, res.AccessControl)
+	}
+	if data.AccessControl == nil {
+		data.AccessControl = []accessControlData{}
+	}
+	data.AssetFolder = dynamicFolderString(res.AssetFolder, data.AssetFolder)
+	data.AssetID = types.String{Value: res.AssetID}
+	data.DisplayName = dynamicFolderString(res.DisplayName, data.DisplayName)
+	data.Bytes = types.Int64{Value: int64(res.Bytes)}
+	data.Etag = types.String{Value: res.Etag}
+	data.Height = types.Int64{Value: int64(res.Height)}
+	data.PublicID = types.String{Value: res.PublicID}
+	data.ResourceType = types.String{Value: res.ResourceType}
+	data.SecureURL = types.String{Value: res.SecureURL}
+	data.Type = types.String{Value: res.Type}
+	data.Version = types.Int64{Value: int64(res.Version)}
+	data.Width = types.Int64{Value: int64(res.Width)}
+	data.ID = types.String{Value: assetID(res.ResourceType, res.Type, res.PublicID)}
+}
+
+// dynamicFolderString returns an attribute of dynamic folder mode. Product
+// environments in fixed folder mode do not return these attributes, in which
+// case the current value is kept.
+func dynamicFolderString(value string, current types.String) types.String {
+	if value != "" {
+		return types.String{Value: value}
+	}
+
+	if current.Unknown {
+		return types.String{Null: true}
+	}
+
+	return current
+}
+
+// invalidate reports whether the cached copies of the asset are to be
+// invalidated.
+func (data assetResourceData) invalidate() bool {
+	return data.Invalidate.Null || data.Invalidate.Unknown || data.Invalidate.Value
+}
+
+// assetID returns the ID of an asset, which identifies it in the Admin API.
+func assetID(resourceType, deliveryType, publicID string) string {
+	return strings.Join([]string{resourceType, deliveryType, publicID}, "/")
+}
+
+// assetFileHash returns the MD5 hash of a local file or inline content, the
+// way Cloudinary computes the etag of an uploaded file. ok is false for
+// remote files, whose content is unknown.
+func assetFileHash(file assetFile) (hash string, ok bool, err error) {
+	h := md5.New()
+
+	switch {
+	case file.URL != "":
+		return "", false, nil
+	case file.Path != "":
+		f, err := os.Open(file.Path)
+		if err != nil {
+			return "", false, err
+		}
+		defer f.Close()
+
+		if _, err := io.Copy(h, f); err != nil {
+			return "", false, err
+		}
+	default:
+		h.Write(file.Content)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), true, nil
+}
+
+// isNotFoundError reports whether an API error message means that the
+// requested resource does not exist.
+func isNotFoundError(message string) bool {
+	return strings.HasPrefix(message, "Resource not found")
+}
+
+type assetResource struct {
+	provider provider
+}
+
+func (r assetResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data assetResourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	n := 0
+	for _, v := range []types.String{data.Source, data.SourceURL, data.ContentBase64} {
+		if !v.Null {
+			n++
+		}
+	}
+
+	if n != 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source"),
+			"Invalid Attribute Combination",
+			"Exactly one of source, source_url or content_base64 must be set.",
+		)
+	}
+
+	if !data.ContentBase64.Null && !data.ContentBase64.Unknown {
+		if _, err := base64.StdEncoding.DecodeString(data.ContentBase64.Value); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("content_base64"),
+				"Invalid Attribute Value",
+				fmt.Sprintf("The content is not valid base64: %s.", err),
+			)
+		}
+	}
+
+	resp.Diagnostics.Append(validateAccessControl(path.Root("access_control"), data.AccessControl)...)
+}
+
+func (r assetResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config assetResourceData
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(warnDynamicFolderAttributes(ctx, r.provider.client, map[string]attr.Value{
+		"asset_folder":                         config.AssetFolder,
+		"display_name":                         config.DisplayName,
+		"use_asset_folder_as_public_id_prefix": config.UseAssetFolderAsPublicIDPrefix,
+	})...)
+
+	// Nothing else to plan on create.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state, plan assetResourceData
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.PublicID.Unknown || !plan.PublicID.Equal(state.PublicID) {
+		plan.markRenamed()
+	}
+
+	if plan.Source.Unknown || plan.SourceURL.Unknown || plan.ContentBase64.Unknown {
+		plan.markUploaded()
+	} else {
+		file, err := plan.file()
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("content_base64"),
+				"Invalid Attribute Value",
+				err.Error(),
+			)
+			return
+		}
+
+		hash, ok, err := assetFileHash(file)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("source"),
+				"Unable to Read Source",
+				fmt.Sprintf("Unable to read the source file, got error: %s", err),
+			)
+			return
+		}
+
+		// Remote files are only uploaded again when their URL changes.
+		if (ok && hash != state.Etag.Value) || (!ok && !plan.SourceURL.Equal(state.SourceURL)) {
+			plan.markUploaded()
+		}
+	}
+
+	diags = resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// markRenamed marks the attributes which change when the asset is renamed
+// as unknown.
+func (data *assetResourceData) markRenamed() {
+	data.ID = types.String{Unknown: true}
+	data.SecureURL = types.String{Unknown: true}
+}
+
+// markUploaded marks the attributes which change when the asset is
+// uploaded again as unknown.
+func (data *assetResourceData) markUploaded() {
+	data.Bytes = types.Int64{Unknown: true}
+	data.Etag = types.String{Unknown: true}
+	data.Height = types.Int64{Unknown: true}
+	data.SecureURL = types.String{Unknown: true}
+	data.Version = types.Int64{Unknown: true}
+	data.Width = types.Int64{Unknown: true}
+}
+
+func (r assetResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data assetResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.upload(ctx, &data, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data assetResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var res assetDetailsResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodGet, api.BuildPath("resources", data.ResourceType.Value, data.Type.Value, data.PublicID.Value), nil, &res)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read asset, got error: %s", err),
+		)
+		return
+	}
+
+	if isNotFoundError(res.Error.Message) {
+		tflog.Warn(ctx, "asset not found, removing it from the state", map[string]interface{}{
+			"id": data.ID.Value,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read asset, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	// Assets without access control are returned with unrestricted anonymous
+	// access.
+	accessControl := res.AccessControl
+	if accessControl == nil {
+		accessControl = []interface{}{}
+	}
+
+	data.refresh(assetResult{
+		UploadResult: uploader.UploadResult{
+			AssetID:      res.AssetID,
+			Bytes:        res.Bytes,
+			Etag:         res.Etag,
+			Height:       res.Height,
+			PublicID:     res.PublicID,
+			ResourceType: res.ResourceType,
+			SecureURL:    res.SecureURL,
+			Type:         res.Type,
+			Version:      res.Version,
+			Width:        res.Width,
+		},
+		AccessControl: accessControl,
+		AssetFolder:   res.AssetFolder,
+		DisplayName:   res.DisplayName,
+	})
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, state assetResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.PublicID.Value != state.PublicID.Value {
+		resp.Diagnostics.Append(r.rename(ctx, &data, state.PublicID.Value)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The etag is only unknown when the plan found the file changed.
+	uploaded := data.Etag.Unknown
+	accessControlChanged := !accessControlEqual(data.AccessControl, state.AccessControl)
+
+	if uploaded {
+		resp.Diagnostics.Append(r.upload(ctx, &data, true)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Uploading again sets the access control but cannot remove it.
+	if (!uploaded && (!data.AssetFolder.Equal(state.AssetFolder) || !data.DisplayName.Equal(state.DisplayName))) || accessControlChanged {
+		resp.Diagnostics.Append(r.update(ctx, &data, accessControlChanged)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data assetResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := uploader.DestroyParams{
+		PublicID:     data.PublicID.Value,
+		Type:         data.Type.Value,
+		ResourceType: data.ResourceType.Value,
+		Invalidate:   data.invalidate(),
+	}
+
+	res, err := r.provider.client.Upload.Destroy(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete asset, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete asset, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	if res.Result != "ok" && res.Result != "not found" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete asset, got result: %s", res.Result),
+		)
+		return
+	}
+}
+
+func (r assetResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	parts := strings.SplitN(req.ID, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: resource_type/type/public_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("public_id"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+type renameAssetResult struct {
+	api.BriefAssetResult
+	Error api.ErrorResp `json:"error,omitempty"`
+}
+
+// rename renames the asset from its previous public ID to the planned one
+// and refreshes data with the result.
+func (r assetResource) rename(ctx context.Context, data *assetResourceData, from string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	params := url.Values{}
+	params.Set("from_public_id", from)
+	params.Set("to_public_id", data.PublicID.Value)
+	params.Set("type", data.Type.Value)
+	params.Set("invalidate", fmt.Sprint(data.invalidate()))
+	if !data.Overwrite.Null && !data.Overwrite.Unknown {
+		params.Set("overwrite", fmt.Sprint(data.Overwrite.Value))
+	}
+
+	var res renameAssetResult
+
+	err := callUploadAPI(ctx, r.provider.client, api.BuildPath(data.ResourceType.Value, "rename"), params, &res)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to rename asset, got error: %s", err),
+		)
+		return diags
+	}
+
+	if res.Error.Message != "" {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to rename asset, got error: %s", res.Error.Message),
+		)
+		return diags
+	}
+
+	tflog.Debug(ctx, "renamed asset", map[string]interface{}{
+		"from": from,
+		"to":   res.PublicID,
+	})
+
+	data.PublicID = types.String{Value: res.PublicID}
+	data.SecureURL = types.String{Value: res.SecureURL}
+	data.ID = types.String{Value: assetID(res.AssetType, res.Type, res.PublicID)}
+
+	return diags
+}
+
+// assetUpdateParams are the parameters of the update asset call which the
+// cloudinary-go client does not support.
+type assetUpdateParams struct {
+	AccessControl []map[string]string `json:"access_control,omitempty"`
+	AssetFolder   string              `json:"asset_folder,omitempty"`
+	DisplayName   string              `json:"display_name,omitempty"`
+}
+
+// update moves the asset to its planned asset folder and sets its display
+// name, and its access control when accessControl is set, and refreshes data
+// with the result.
+func (r assetResource) update(ctx context.Context, data *assetResourceData, accessControl bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	params := assetUpdateParams{
+		AssetFolder: data.AssetFolder.Value,
+		DisplayName: data.DisplayName.Value,
+	}
+
+	if accessControl {
+		params.AccessControl = expandAccessControl(data.AccessControl)
+
+		// Access control is removed by allowing unrestricted anonymous
+		// access.
+		if len(params.AccessControl) == 0 {
+			params.AccessControl = []map[string]string{{"access_type": anonymousAccessType}}
+		}
+	}
+
+	var res assetDetailsResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodPost, api.BuildPath("resources", data.ResourceType.Value, data.Type.Value, data.PublicID.Value), params, &res)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update asset, got error: %s", err),
+		)
+		return diags
+	}
+
+	if res.Error.Message != "" {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update asset, got error: %s", res.Error.Message),
+		)
+		return diags
+	}
+
+	data.AssetFolder = dynamicFolderString(res.AssetFolder, data.AssetFolder)
+	data.DisplayName = dynamicFolderString(res.DisplayName, data.DisplayName)
+
+	if res.AccessControl != nil {
+		data.AccessControl = flattenAccessControl(data.AccessControl, res.AccessControl)
+	}
+
+	return diags
+}
+
+// upload uploads the file of the asset and refreshes data with the result.
+// When overwrite is set, the cached copies of the previous file are
+// invalidated.
+func (r assetResource) upload(ctx context.Context, data *assetResourceData, overwrite bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	file, err := data.file()
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("content_base64"),
+			"Invalid Attribute Value",
+			err.Error(),
+		)
+		return diags
+	}
+
+	resourceType := "image"
+	if !data.ResourceType.Null && !data.ResourceType.Unknown {
+		resourceType = data.ResourceType.Value
+	}
+
+	params := url.Values{}
+	if !data.PublicID.Null && !data.PublicID.Unknown {
+		params.Set("public_id", data.PublicID.Value)
+	}
+	if !data.Type.Null && !data.Type.Unknown {
+		params.Set("type", data.Type.Value)
+	}
+	if !data.AssetFolder.Null && !data.AssetFolder.Unknown {
+		params.Set("asset_folder", data.AssetFolder.Value)
+	}
+	if !data.DisplayName.Null && !data.DisplayName.Unknown {
+		params.Set("display_name", data.DisplayName.Value)
+	}
+	if !data.UseAssetFolderAsPublicIDPrefix.Null && !data.UseAssetFolderAsPublicIDPrefix.Unknown {
+		params.Set("use_asset_folder_as_public_id_prefix", fmt.Sprint(data.UseAssetFolderAsPublicIDPrefix.Value))
+	}
+	if len(data.AccessControl) > 0 {
+		accessControl, err := json.Marshal(expandAccessControl(data.AccessControl))
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("access_control"),
+				"Invalid Attribute Value",
+				err.Error(),
+			)
+			return diags
+		}
+		params.Set("access_control", string(accessControl))
+	}
+	// Signed uploads overwrite existing assets by default, so it is
+	// disabled explicitly to never replace an asset unknown to Terraform.
+	if overwrite {
+		params.Set("overwrite", "true")
+		params.Set("invalidate", fmt.Sprint(data.invalidate()))
+	} else {
+		params.Set("overwrite", "false")
+	}
+
+	var res assetResult
+
+	err = uploadAsset(ctx, r.provider.client, r.provider.chunkedUploadThreshold, resourceType, file, params, &res)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to upload asset, got error: %s", err),
+		)
+		return diags
+	}
+
+	if res.Error.Message != "" {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to upload asset, got error: %s", res.Error.Message),
+		)
+		return diags
+	}
+
+	// Cloudinary returns the existing asset unchanged instead of an error.
+	if !overwrite && res.Existing {
+		diags.AddError(
+			"Asset Already Exists",
+			fmt.Sprintf("The asset %s already exists. Import it to manage it with Terraform.", assetID(res.ResourceType, res.Type, res.PublicID)),
+		)
+		return diags
+	}
+
+	data.refresh(res)
+
+	return diags
+}
@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// 1x1 pixel PNG images.
+const (
+	testAccRedPixel  = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAIAAACQd1PeAAAADElEQVR4nGP4z8AAAAMBAQDJ/pLvAAAAAElFTkSuQmCC"
+	testAccBluePixel = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAIAAACQd1PeAAAADElEQVR4nGNgYPgPAAEDAQAIicLsAAAAAElFTkSuQmCC"
+)
+
+func TestAccAssetResource(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "pixel.png")
+
+	writePixel := func(pixel string) func() {
+		return func() {
+			b, _ := base64.StdEncoding.DecodeString(pixel)
+			if err := os.WriteFile(source, b, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	var version string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				PreConfig: writePixel(testAccRedPixel),
+				Config:    testAccAssetResourceConfig(source, "terraform_acc_test/pixel"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_asset.test", "id", "image/upload/terraform_acc_test/pixel"),
+					resource.TestCheckResourceAttr("cloudinary_asset.test", "resource_type", "image"),
+					resource.TestCheckResourceAttr("cloudinary_asset.test", "type", "upload"),
+					resource.TestCheckResourceAttr("cloudinary_asset.test", "width", "1"),
+					resource.TestCheckResourceAttr("cloudinary_asset.test", "height", "1"),
+					resource.TestCheckResourceAttrSet("cloudinary_asset.test", "secure_url"),
+					resource.TestCheckResourceAttrWith("cloudinary_asset.test", "version", func(v string) error {
+						version = v
+						return nil
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "cloudinary_asset.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"source"},
+			},
+			// Changing the file uploads it again
+			{
+				PreConfig: writePixel(testAccBluePixel),
+				Config:    testAccAssetResourceConfig(source, "terraform_acc_test/pixel"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("cloudinary_asset.test", "version", func(v string) error {
+						if v == version {
+							return fmt.Errorf("version did not change")
+						}
+						version = v
+						return nil
+					}),
+				),
+			},
+			// Changing the public ID renames the asset
+			{
+				Config: testAccAssetResourceConfig(source, "terraform_acc_test/pixel_renamed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_asset.test", "id", "image/upload/terraform_acc_test/pixel_renamed"),
+					resource.TestCheckResourceAttrWith("cloudinary_asset.test", "version", func(v string) error {
+						if v != version {
+							return fmt.Errorf("version changed from %s to %s on rename", version, v)
+						}
+						return nil
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccAssetResource_contentBase64(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "cloudinary_asset" "test" {
+  content_base64 = %[1]q
+  type           = "private"
+}
+`, testAccRedPixel),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("cloudinary_asset.test", "public_id"),
+					resource.TestCheckResourceAttr("cloudinary_asset.test", "type", "private"),
+					resource.TestCheckResourceAttr("cloudinary_asset.test", "bytes", "69"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResource_existing(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "cloudinary_asset" "test" {
+  content_base64 = %[1]q
+  public_id      = "terraform_acc_test/existing"
+}
+
+resource "cloudinary_asset" "duplicate" {
+  content_base64 = %[2]q
+  public_id      = cloudinary_asset.test.public_id
+}
+`, testAccRedPixel, testAccBluePixel),
+				ExpectError: regexp.MustCompile(`already exists`),
+			},
+		},
+	})
+}
+
+func TestAccAssetResource_accessControl(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAssetResourceAccessControlConfig(`
+  access_control {
+    access_type = "token"
+  }
+
+  access_control {
+    access_type = "anonymous"
+    start       = "2030-01-01T09:00:00+09:00"
+    end         = "2030-01-31T00:00:00Z"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_asset.test", "access_control.#", "2"),
+					resource.TestCheckResourceAttr("cloudinary_asset.test", "access_control.1.start", "2030-01-01T09:00:00+09:00"),
+				),
+			},
+			// Removing the access control makes the asset public
+			{
+				Config: testAccAssetResourceAccessControlConfig(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_asset.test", "access_control.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAssetResourceAccessControlConfig(accessControl string) string {
+	return fmt.Sprintf(`
+resource "cloudinary_asset" "test" {
+  content_base64 = %[1]q
+  public_id      = "terraform_acc_test/access_control"
+%[2]s}
+`, testAccRedPixel, accessControl)
+}
+
+func testAccAssetResourceConfig(source string, publicID string) string {
+	return fmt.Sprintf(`
+resource "cloudinary_asset" "test" {
+  source    = %[1]q
+  public_id = %[2]q
+}
+`, source, publicID)
+}
+
+func TestAssetFileHash(t *testing.T) {
+	content, _ := base64.StdEncoding.DecodeString(testAccRedPixel)
+
+	source := filepath.Join(t.TempDir(), "pixel.png")
+	if err := os.WriteFile(source, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fromContent, ok, err := assetFileHash(assetFile{Content: content})
+	if err != nil || !ok {
+		t.Fatalf("assetFileHash(content) = %q, %t, %v", fromContent, ok, err)
+	}
+
+	fromPath, ok, err := assetFileHash(assetFile{Path: source})
+	if err != nil || !ok {
+		t.Fatalf("assetFileHash(path) = %q, %t, %v", fromPath, ok, err)
+	}
+
+	if fromContent != fromPath {
+		t.Errorf("hashes differ: %q != %q", fromContent, fromPath)
+	}
+
+	if _, ok, _ := assetFileHash(assetFile{URL: "https://example.com/pixel.png"}); ok {
+		t.Error("remote files must not be hashed")
+	}
+
+	if _, _, err := assetFileHash(assetFile{Path: filepath.Join(t.TempDir(), "missing.png")}); err == nil {
+		t.Error("missing files must be an error")
+	}
+}
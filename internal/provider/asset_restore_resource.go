@@ -0,0 +1,290 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type assetRestoreResourceType struct{}
+
+func (t assetRestoreResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Asset Restore resource. Restores a backed up version of an asset, or a deleted asset, when the resource is created. The restore is not checked again on refresh and destroying the resource keeps the asset as it is.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"bytes": {
+				MarkdownDescription: "The size of the restored asset in bytes.",
+				Computed:            true,
+				Type:                types.Int64Type,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"id": {
+				MarkdownDescription: "The ID of the asset in the `resource_type/type/public_id` form.",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"public_id": {
+				MarkdownDescription: "The public ID of the asset.",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"resource_type": {
+				MarkdownDescription: "The resource type of the asset. One of `image` (default), `video` or `raw`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"image", "raw", "video"}},
+				},
+			},
+			"secure_url": {
+				MarkdownDescription: "The HTTPS delivery URL of the restored asset.",
+				Computed:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"type": {
+				MarkdownDescription: "The delivery type of the asset. One of `upload` (default), `private` or `authenticated`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"authenticated", "private", "upload"}},
+				},
+			},
+			"version": {
+				MarkdownDescription: "The version of the restored asset.",
+				Computed:            true,
+				Type:                types.Int64Type,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"version_id": {
+				MarkdownDescription: "The ID of the backed up version to restore, as listed by `cloudinary_asset_versions`. The latest backed up version is restored when omitted.",
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+		},
+	}, nil
+}
+
+func (t assetRestoreResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return assetRestoreResource{
+		provider: provider,
+	}, diags
+}
+
+type assetRestoreResourceData struct {
+	Bytes        types.Int64  `tfsdk:"bytes"`
+	ID           types.String `tfsdk:"id"`
+	PublicID     types.String `tfsdk:"public_id"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	SecureURL    types.String `tfsdk:"secure_url"`
+	Type         types.String `tfsdk:"type"`
+	Version      types.Int64  `tfsdk:"version"`
+	VersionID    types.String `tfsdk:"version_id"`
+}
+
+// setDefaults fills the optional attributes which are not configured with
+// their default values.
+func (data *assetRestoreResourceData) setDefaults() {
+	if data.ResourceType.Null || data.ResourceType.Unknown {
+		data.ResourceType = types.String{Value: "image"}
+	}
+	if data.Type.Null || data.Type.Unknown {
+		data.Type = types.String{Value: "upload"}
+	}
+}
+
+// restoreAssetsParams are the parameters of the restore call.
+type restoreAssetsParams struct {
+	PublicIDs []string `json:"public_ids"`
+	Versions  []string `json:"versions,omitempty"`
+}
+
+// restoredAsset is an asset in the result of the restore call. Its error is
+// either a message or an object with a message.
+type restoredAsset struct {
+	api.BriefAssetResult
+	Error interface{} `json:"error"`
+}
+
+// errorMessage returns the message of the error of the asset, if any.
+func (a restoredAsset) errorMessage() string {
+	switch v := a.Error.(type) {
+	case nil:
+		return ""
+	case map[string]interface{}:
+		return fmt.Sprint(v["message"])
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// restoreAssetsResult is the result of the restore call, which holds the
+// restored asset or an error by public ID.
+type restoreAssetsResult struct {
+	Assets map[string]restoredAsset
+	Error  api.ErrorResp
+}
+
+func (res *restoreAssetsResult) UnmarshalJSON(b []byte) error {
+	var errorResp struct {
+		Error api.ErrorResp `json:"error"`
+	}
+	if err := json.Unmarshal(b, &errorResp); err == nil && errorResp.Error.Message != "" {
+		res.Error = errorResp.Error
+		return nil
+	}
+
+	return json.Unmarshal(b, &res.Assets)
+}
+
+type assetRestoreResource struct {
+	provider provider
+}
+
+func (r assetRestoreResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data assetRestoreResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setDefaults()
+
+	params := restoreAssetsParams{
+		PublicIDs: []string{data.PublicID.Value},
+	}
+	if !data.VersionID.Null && !data.VersionID.Unknown {
+		params.Versions = []string{data.VersionID.Value}
+	}
+
+	var res restoreAssetsResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodPost, api.BuildPath("resources", data.ResourceType.Value, data.Type.Value, "restore"), params, &res)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to restore asset, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to restore asset, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	asset, ok := res.Assets[data.PublicID.Value]
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to restore asset, %s is missing from the result", data.PublicID.Value),
+		)
+		return
+	}
+
+	if message := asset.errorMessage(); message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to restore asset, got error: %s", message),
+		)
+		return
+	}
+
+	data.Bytes = types.Int64{Value: int64(asset.Bytes)}
+	data.ID = types.String{Value: assetID(data.ResourceType.Value, data.Type.Value, data.PublicID.Value)}
+	data.SecureURL = types.String{Value: asset.SecureURL}
+	data.Version = types.Int64{Value: int64(asset.Version)}
+
+	tflog.Trace(ctx, "created a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetRestoreResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data assetRestoreResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The restore happened once, so there is nothing to refresh.
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetRestoreResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data assetRestoreResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every attribute requires replacement, so there is nothing to update.
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetRestoreResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data assetRestoreResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A restore cannot be undone, so the asset is kept as it is.
+	tflog.Debug(ctx, "keeping the restored asset", map[string]interface{}{
+		"id": data.ID.Value,
+	})
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAssetRestoreResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAssetRestoreResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_asset_restore.test", "id", "image/upload/terraform_acc_test/restore"),
+					resource.TestCheckResourceAttrPair("cloudinary_asset_restore.test", "version_id", "data.cloudinary_asset_versions.test", "versions.0.version_id"),
+					resource.TestCheckResourceAttrSet("cloudinary_asset_restore.test", "version"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+var testAccAssetRestoreResourceConfig = fmt.Sprintf(`
+resource "cloudinary_asset" "test" {
+  content_base64 = %[1]q
+  public_id      = "terraform_acc_test/restore"
+}
+
+data "cloudinary_asset_versions" "test" {
+  public_id = cloudinary_asset.test.public_id
+}
+
+resource "cloudinary_asset_restore" "test" {
+  public_id  = cloudinary_asset.test.public_id
+  version_id = data.cloudinary_asset_versions.test.versions[0].version_id
+}
+`, testAccRedPixel)
+
+func TestRestoreAssetsResult(t *testing.T) {
+	tests := []struct {
+		body    string
+		message string
+		error   string
+		version int
+	}{
+		{`{"logo":{"public_id":"logo","version":1660000000}}`, "", "", 1660000000},
+		{`{"logo":{"error":"No backup found"}}`, "", "No backup found", 0},
+		{`{"logo":{"error":{"message":"No backup found"}}}`, "", "No backup found", 0},
+		{`{"error":{"message":"Invalid type"}}`, "Invalid type", "", 0},
+	}
+
+	for _, tt := range tests {
+		var res restoreAssetsResult
+		if err := json.Unmarshal([]byte(tt.body), &res); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", tt.body, err)
+		}
+
+		if res.Error.Message != tt.message {
+			t.Errorf("Unmarshal(%s) message = %q, want %q", tt.body, res.Error.Message, tt.message)
+		}
+
+		if tt.message != "" {
+			continue
+		}
+
+		asset := res.Assets["logo"]
+		if got := asset.errorMessage(); got != tt.error {
+			t.Errorf("Unmarshal(%s) error = %q, want %q", tt.body, got, tt.error)
+		}
+
+		if asset.Version != tt.version {
+			t.Errorf("Unmarshal(%s) version = %d, want %d", tt.body, asset.Version, tt.version)
+		}
+	}
+}
@@ -0,0 +1,543 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/cloudinary/cloudinary-go/api/admin"
+	"github.com/cloudinary/cloudinary-go/api/uploader"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// additiveTagsMode adds the tags to the assets and keeps their other
+	// tags.
+	additiveTagsMode = "additive"
+
+	// authoritativeTagsMode replaces all tags of the assets.
+	authoritativeTagsMode = "authoritative"
+
+	// maxTagsPublicIDs is the number of public IDs accepted by a tags call.
+	maxTagsPublicIDs = 1000
+
+	// maxAssetsByIDs is the number of public IDs accepted by a get resources
+	// call.
+	maxAssetsByIDs = 100
+)
+
+type assetTagsResourceType struct{}
+
+func (t assetTagsResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Asset Tags resource. Manages the tags of existing assets without managing the assets themselves.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"mode": {
+				MarkdownDescription: "Either `additive` (default), which adds the tags and keeps the other tags of the assets, or `authoritative`, which replaces all tags of the assets.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{additiveTagsMode, authoritativeTagsMode}},
+				},
+			},
+			"public_ids": {
+				MarkdownDescription: "The public IDs of the assets.",
+				Required:            true,
+				Type:                types.SetType{ElemType: types.StringType},
+			},
+			"resource_type": {
+				MarkdownDescription: "The resource type of the assets. One of `image` (default), `video` or `raw`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"image", "raw", "video"}},
+				},
+			},
+			"tags": {
+				MarkdownDescription: "The tags of the assets. Tags cannot contain commas.",
+				Required:            true,
+				Type:                types.SetType{ElemType: types.StringType},
+			},
+			"type": {
+				MarkdownDescription: "The delivery type of the assets. One of `upload` (default), `private` or `authenticated`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"authenticated", "private", "upload"}},
+				},
+			},
+		},
+	}, nil
+}
+
+func (t assetTagsResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return assetTagsResource{
+		provider: provider,
+	}, diags
+}
+
+type assetTagsResourceData struct {
+	ID           types.String `tfsdk:"id"`
+	Mode         types.String `tfsdk:"mode"`
+	PublicIDs    types.Set    `tfsdk:"public_ids"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	Tags         types.Set    `tfsdk:"tags"`
+	Type         types.String `tfsdk:"type"`
+}
+
+// setDefaults fills the optional attributes which are not configured with
+// their default values.
+func (data *assetTagsResourceData) setDefaults() {
+	if data.ResourceType.Null || data.ResourceType.Unknown {
+		data.ResourceType = types.String{Value: "image"}
+	}
+	if data.Type.Null || data.Type.Unknown {
+		data.Type = types.String{Value: "upload"}
+	}
+}
+
+// authoritative reports whether the tags replace all tags of the assets. The
+// mode is additive when it is not configured.
+func (data assetTagsResourceData) authoritative() bool {
+	return data.Mode.Value == authoritativeTagsMode
+}
+
+type assetTagsResource struct {
+	provider provider
+}
+
+func (r assetTagsResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data assetTagsResourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Tags.Unknown {
+		return
+	}
+
+	for _, v := range data.Tags.Elems {
+		tag, ok := v.(types.String)
+		if !ok || tag.Unknown {
+			continue
+		}
+
+		if tag.Value == "" || strings.Contains(tag.Value, ",") {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tags"),
+				"Invalid Attribute Value",
+				fmt.Sprintf("The tag %q is invalid, tags must not be empty or contain commas.", tag.Value),
+			)
+		}
+	}
+}
+
+func (r assetTagsResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data assetTagsResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setDefaults()
+
+	var publicIDs, tags []string
+	resp.Diagnostics.Append(data.PublicIDs.ElementsAs(ctx, &publicIDs, false)...)
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data, tags, publicIDs)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.String{Value: assetsID(data.ResourceType.Value, data.Type.Value, publicIDs)}
+
+	tflog.Trace(ctx, "created a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetTagsResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data assetTagsResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var publicIDs, tags []string
+	resp.Diagnostics.Append(data.PublicIDs.ElementsAs(ctx, &publicIDs, false)...)
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assets, diags := getAssetsByIDs(ctx, r.provider, data.ResourceType.Value, data.Type.Value, publicIDs, admin.AssetsByIDsParams{Tags: true})
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(assets) == 0 {
+		tflog.Warn(ctx, "assets not found, removing the tags from the state", map[string]interface{}{
+			"id": data.ID.Value,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	found := make([]string, 0, len(assets))
+	assetTags := make([][]string, 0, len(assets))
+	for _, asset := range assets {
+		found = append(found, asset.PublicID)
+		assetTags = append(assetTags, asset.Tags)
+	}
+
+	data.PublicIDs = stringSet(found)
+	data.Tags = stringSet(observedTags(tags, assetTags, data.authoritative()))
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetTagsResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, state assetTagsResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setDefaults()
+
+	var publicIDs, tags, currentPublicIDs, currentTags []string
+	resp.Diagnostics.Append(data.PublicIDs.ElementsAs(ctx, &publicIDs, false)...)
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+	resp.Diagnostics.Append(state.PublicIDs.ElementsAs(ctx, &currentPublicIDs, false)...)
+	resp.Diagnostics.Append(state.Tags.ElementsAs(ctx, &currentTags, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Assets which are no longer managed lose the tags of the resource.
+	resp.Diagnostics.Append(r.tag(ctx, data, "remove", currentTags, difference(currentPublicIDs, publicIDs))...)
+
+	// Replacing the tags removes the tags which are no longer configured.
+	if !data.authoritative() {
+		resp.Diagnostics.Append(r.tag(ctx, data, "remove", difference(currentTags, tags), publicIDs)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data, tags, publicIDs)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.String{Value: assetsID(data.ResourceType.Value, data.Type.Value, publicIDs)}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r assetTagsResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data assetTagsResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var publicIDs, tags []string
+	resp.Diagnostics.Append(data.PublicIDs.ElementsAs(ctx, &publicIDs, false)...)
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.tag(ctx, data, "remove", tags, publicIDs)...)
+}
+
+// apply gives the tags to the assets according to the mode of the resource.
+func (r assetTagsResource) apply(ctx context.Context, data assetTagsResourceData, tags []string, publicIDs []string) diag.Diagnostics {
+	if !data.authoritative() {
+		return r.tag(ctx, data, "add", tags, publicIDs)
+	}
+
+	if len(tags) == 0 {
+		return r.tag(ctx, data, "remove_all", nil, publicIDs)
+	}
+
+	return r.tag(ctx, data, "replace", tags, publicIDs)
+}
+
+// tag runs a command of the tags call for the assets, in batches of the
+// number of public IDs a call accepts.
+func (r assetTagsResource) tag(ctx context.Context, data assetTagsResourceData, command string, tags []string, publicIDs []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if len(publicIDs) == 0 || (len(tags) == 0 && command != "remove_all") {
+		return diags
+	}
+
+	tag := strings.Join(tags, ",")
+
+	for _, batch := range batchStrings(publicIDs, maxTagsPublicIDs) {
+		var (
+			res *uploader.TagResult
+			err error
+		)
+
+		switch command {
+		case "add":
+			var v *uploader.AddTagResult
+			v, err = r.provider.client.Upload.AddTag(ctx, uploader.AddTagParams{
+				Tag:          tag,
+				PublicIDs:    batch,
+				Type:         data.Type.Value,
+				ResourceType: data.ResourceType.Value,
+			})
+			if v != nil {
+				res = &v.TagResult
+			}
+		case "remove":
+			var v *uploader.RemoveTagResult
+			v, err = r.provider.client.Upload.RemoveTag(ctx, uploader.RemoveTagParams{
+				Tag:          tag,
+				PublicIDs:    batch,
+				Type:         data.Type.Value,
+				ResourceType: data.ResourceType.Value,
+			})
+			if v != nil {
+				res = &v.TagResult
+			}
+		case "replace":
+			var v *uploader.ReplaceTagResult
+			v, err = r.provider.client.Upload.ReplaceTag(ctx, uploader.ReplaceTagParams{
+				Tag:          tag,
+				PublicIDs:    batch,
+				Type:         data.Type.Value,
+				ResourceType: data.ResourceType.Value,
+			})
+			if v != nil {
+				res = &v.TagResult
+			}
+		case "remove_all":
+			var v *uploader.RemoveAllTagsResult
+			v, err = r.provider.client.Upload.RemoveAllTags(ctx, uploader.RemoveAllTagsParams{
+				PublicIDs:    batch,
+				Type:         data.Type.Value,
+				ResourceType: data.ResourceType.Value,
+			})
+			if v != nil {
+				res = &v.TagResult
+			}
+		}
+
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to %s tags, got error: %s", strings.ReplaceAll(command, "_", " "), err),
+			)
+			return diags
+		}
+
+		if res.Error.Message != "" {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to %s tags, got error: %s", strings.ReplaceAll(command, "_", " "), res.Error.Message),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// getAssetsByIDs returns the assets with the public IDs, in batches of the
+// number of public IDs a call accepts. Assets which do not exist are left
+// out. Which details are returned is set by the flags of params.
+func getAssetsByIDs(ctx context.Context, p provider, resourceType string, deliveryType string, publicIDs []string, params admin.AssetsByIDsParams) ([]api.BriefAssetResult, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var assets []api.BriefAssetResult
+
+	params.AssetType = api.AssetType(resourceType)
+	params.DeliveryType = api.DeliveryType(deliveryType)
+
+	for _, batch := range batchStrings(publicIDs, maxAssetsByIDs) {
+		params.PublicIDs = batch
+
+		res, err := p.client.Admin.AssetsByIDs(ctx, params)
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to read assets, got error: %s", err),
+			)
+			return nil, diags
+		}
+
+		if res.Error.Message != "" {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to read assets, got error: %s", res.Error.Message),
+			)
+			return nil, diags
+		}
+
+		assets = append(assets, res.Assets...)
+	}
+
+	return assets, diags
+}
+
+// observedTags returns the tags to store in the state given the tags of each
+// asset. Managed tags which are missing from any asset are left out, so that
+// they are given again. In authoritative mode, the tags of any asset which
+// are not managed are included, so that they are removed again.
+func observedTags(managed []string, assetTags [][]string, authoritative bool) []string {
+	var tags []string
+
+	for _, tag := range managed {
+		everywhere := true
+		for _, t := range assetTags {
+			if !containsString(t, tag) {
+				everywhere = false
+				break
+			}
+		}
+
+		if everywhere {
+			tags = append(tags, tag)
+		}
+	}
+
+	if authoritative {
+		for _, t := range assetTags {
+			for _, tag := range difference(t, managed) {
+				if !containsString(tags, tag) {
+					tags = append(tags, tag)
+				}
+			}
+		}
+	}
+
+	sort.Strings(tags)
+
+	return tags
+}
+
+// assetsID returns the ID of a resource which manages several assets.
+func assetsID(resourceType string, deliveryType string, publicIDs []string) string {
+	ids := append([]string{}, publicIDs...)
+	sort.Strings(ids)
+
+	return assetID(resourceType, deliveryType, strings.Join(ids, ","))
+}
+
+// stringSet returns a set of strings.
+func stringSet(values []string) types.Set {
+	set := types.Set{ElemType: types.StringType, Elems: []attr.Value{}}
+
+	for _, v := range values {
+		set.Elems = append(set.Elems, types.String{Value: v})
+	}
+
+	return set
+}
+
+// difference returns the strings of a which are not in b.
+func difference(a []string, b []string) []string {
+	var d []string
+
+	for _, s := range a {
+		if !containsString(b, s) {
+			d = append(d, s)
+		}
+	}
+
+	return d
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// batchStrings splits values into batches of at most size values.
+func batchStrings(values []string, size int) [][]string {
+	var batches [][]string
+
+	for len(values) > size {
+		batches = append(batches, values[:size])
+		values = values[size:]
+	}
+
+	if len(values) > 0 {
+		batches = append(batches, values)
+	}
+
+	return batches
+}
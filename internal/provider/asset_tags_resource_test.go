@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAssetTagsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAssetTagsResourceConfig(`["logo", "brand"]`, "additive"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_asset_tags.test", "id", "image/upload/terraform_acc_test/tags"),
+					resource.TestCheckResourceAttr("cloudinary_asset_tags.test", "tags.#", "2"),
+					resource.TestCheckResourceAttr("cloudinary_asset_tags.test", "mode", "additive"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccAssetTagsResourceConfig(`["brand"]`, "authoritative"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_asset_tags.test", "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr("cloudinary_asset_tags.test", "tags.*", "brand"),
+				),
+			},
+			// Removing the mode restores the additive default
+			{
+				Config: testAccAssetTagsResourceConfig(`["brand"]`, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("cloudinary_asset_tags.test", "mode"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAssetTagsResourceConfig(tags string, mode string) string {
+	if mode != "" {
+		mode = fmt.Sprintf("mode       = %q", mode)
+	}
+
+	return fmt.Sprintf(`
+resource "cloudinary_asset" "test" {
+  content_base64 = %[1]q
+  public_id      = "terraform_acc_test/tags"
+}
+
+resource "cloudinary_asset_tags" "test" {
+  public_ids = [cloudinary_asset.test.public_id]
+  tags       = %[2]s
+  %[3]s
+}
+`, testAccRedPixel, tags, mode)
+}
+
+func TestObservedTags(t *testing.T) {
+	tests := []struct {
+		managed       []string
+		assetTags     [][]string
+		authoritative bool
+		want          []string
+	}{
+		{[]string{"a", "b"}, [][]string{{"a", "b", "c"}, {"b", "a"}}, false, []string{"a", "b"}},
+		{[]string{"a", "b"}, [][]string{{"a", "b"}, {"a"}}, false, []string{"a"}},
+		{[]string{"a", "b"}, [][]string{{"a", "b", "c"}, {"a", "b"}}, true, []string{"a", "b", "c"}},
+		{[]string{"a", "b"}, [][]string{{"a"}, {"a", "b"}}, true, []string{"a"}},
+		{nil, [][]string{{"a"}}, true, []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		got := observedTags(tt.managed, tt.assetTags, tt.authoritative)
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("observedTags(%v, %v, %t) = %v, want %v", tt.managed, tt.assetTags, tt.authoritative, got, tt.want)
+		}
+	}
+}
+
+func TestBatchStrings(t *testing.T) {
+	values := []string{"a", "b", "c", "d", "e"}
+
+	if got := fmt.Sprint(batchStrings(values, 2)); got != "[[a b] [c d] [e]]" {
+		t.Errorf("batchStrings(%v, 2) = %s", values, got)
+	}
+
+	if got := len(batchStrings(nil, 2)); got != 0 {
+		t.Errorf("batchStrings(nil, 2) returned %d batches", got)
+	}
+}
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type assetVersionsDataSourceType struct{}
+
+func (t assetVersionsDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Asset versions data source. Lists the backed up versions of an asset, which can be restored with `cloudinary_asset_restore`. Requires backups to be enabled.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "The ID of the asset in the `resource_type/type/public_id` form.",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"public_id": {
+				MarkdownDescription: "The public ID of the asset.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"resource_type": {
+				MarkdownDescription: "The resource type of the asset. One of `image` (default), `video` or `raw`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"image", "raw", "video"}},
+				},
+			},
+			"type": {
+				MarkdownDescription: "The delivery type of the asset. One of `upload` (default), `private` or `authenticated`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"authenticated", "private", "upload"}},
+				},
+			},
+			"versions": {
+				MarkdownDescription: "The backed up versions of the asset.",
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"bytes": {
+							MarkdownDescription: "The size of the version in bytes.",
+							Computed:            true,
+							Type:                types.Int64Type,
+						},
+						"format": {
+							MarkdownDescription: "The format of the version.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"restorable": {
+							MarkdownDescription: "Whether the version can be restored.",
+							Computed:            true,
+							Type:                types.BoolType,
+						},
+						"time": {
+							MarkdownDescription: "The RFC 3339 timestamp when the version was backed up.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"version": {
+							MarkdownDescription: "The version number of the asset, as used in delivery URLs.",
+							Computed:            true,
+							Type:                types.Int64Type,
+						},
+						"version_id": {
+							MarkdownDescription: "The ID of the backed up version.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+					},
+				),
+				Computed: true,
+			},
+		},
+	}, nil
+}
+
+func (t assetVersionsDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return assetVersionsDataSource{
+		provider: provider,
+	}, diags
+}
+
+type assetVersionData struct {
+	Bytes      types.Int64  `tfsdk:"bytes"`
+	Format     types.String `tfsdk:"format"`
+	Restorable types.Bool   `tfsdk:"restorable"`
+	Time       types.String `tfsdk:"time"`
+	Version    types.Int64  `tfsdk:"version"`
+	VersionID  types.String `tfsdk:"version_id"`
+}
+
+type assetVersionsDataSourceData struct {
+	ID           types.String       `tfsdk:"id"`
+	PublicID     types.String       `tfsdk:"public_id"`
+	ResourceType types.String       `tfsdk:"resource_type"`
+	Type         types.String       `tfsdk:"type"`
+	Versions     []assetVersionData `tfsdk:"versions"`
+}
+
+// assetVersionsParams are the parameters of the asset details call which
+// include the backed up versions.
+type assetVersionsParams struct {
+	Versions bool `json:"versions"`
+}
+
+// assetVersionsResult is the result of the asset details call including the
+// backed up versions.
+type assetVersionsResult struct {
+	Versions []struct {
+		VersionID  string `json:"version_id"`
+		Version    int64  `json:"version"`
+		Format     string `json:"format"`
+		Size       int64  `json:"size"`
+		Time       string `json:"time"`
+		Restorable bool   `json:"restorable"`
+	} `json:"versions"`
+	Error api.ErrorResp `json:"error,omitempty"`
+}
+
+type assetVersionsDataSource struct {
+	provider provider
+}
+
+func (d assetVersionsDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data assetVersionsDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ResourceType.Null {
+		data.ResourceType = types.String{Value: "image"}
+	}
+	if data.Type.Null {
+		data.Type = types.String{Value: "upload"}
+	}
+
+	data.ID = types.String{Value: assetID(data.ResourceType.Value, data.Type.Value, data.PublicID.Value)}
+
+	var res assetVersionsResult
+
+	err := callAdminAPI(ctx, d.provider.client, http.MethodGet, api.BuildPath("resources", data.ResourceType.Value, data.Type.Value, data.PublicID.Value), assetVersionsParams{Versions: true}, &res)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read asset versions, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read asset versions, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	data.Versions = []assetVersionData{}
+	for _, v := range res.Versions {
+		data.Versions = append(data.Versions, assetVersionData{
+			Bytes:      types.Int64{Value: v.Size},
+			Format:     types.String{Value: v.Format},
+			Restorable: types.Bool{Value: v.Restorable},
+			Time:       types.String{Value: v.Time},
+			Version:    types.Int64{Value: v.Version},
+			VersionID:  types.String{Value: v.VersionID},
+		})
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
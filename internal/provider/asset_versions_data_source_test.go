@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAssetVersionsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAssetVersionsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudinary_asset_versions.test", "id", "image/upload/terraform_acc_test/versions"),
+					resource.TestCheckResourceAttrSet("data.cloudinary_asset_versions.test", "versions.#"),
+				),
+			},
+		},
+	})
+}
+
+var testAccAssetVersionsDataSourceConfig = fmt.Sprintf(`
+resource "cloudinary_asset" "test" {
+  content_base64 = %[1]q
+  public_id      = "terraform_acc_test/versions"
+}
+
+data "cloudinary_asset_versions" "test" {
+  public_id = cloudinary_asset.test.public_id
+}
+`, testAccRedPixel)
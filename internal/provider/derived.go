@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go"
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/cloudinary/cloudinary-go/api/admin"
+)
+
+// derivedPollInterval is the delay between two checks of the derived
+// resources of an asset while eager transformations are processed.
+var derivedPollInterval = 5 * time.Second
+
+// derivedResource is a derived resource in the asset details.
+type derivedResource struct {
+	Bytes          int64  `json:"bytes"`
+	Format         string `json:"format"`
+	ID             string `json:"id"`
+	SecureURL      string `json:"secure_url"`
+	Transformation string `json:"transformation"`
+	URL            string `json:"url"`
+}
+
+// derivedResourcesParams are the parameters of the asset details call which
+// page through the derived resources of the asset.
+type derivedResourcesParams struct {
+	DerivedNextCursor string `json:"derived_next_cursor,omitempty"`
+	MaxResults        int    `json:"max_results,omitempty"`
+}
+
+// derivedResourcesResult is the result of the asset details call, reduced to
+// the derived resources of the asset.
+type derivedResourcesResult struct {
+	Derived           []derivedResource `json:"derived"`
+	DerivedNextCursor string            `json:"derived_next_cursor"`
+	Error             api.ErrorResp     `json:"error,omitempty"`
+}
+
+// getDerivedResources returns every derived resource of an asset, following
+// the derived cursor of the asset details.
+func getDerivedResources(ctx context.Context, client *cloudinary.Cloudinary, resourceType, deliveryType, publicID string) (*derivedResourcesResult, error) {
+	result := &derivedResourcesResult{}
+
+	params := derivedResourcesParams{
+		MaxResults: maxListedAssets,
+	}
+
+	for {
+		var res derivedResourcesResult
+
+		err := callAdminAPI(ctx, client, http.MethodGet, api.BuildPath("resources", resourceType, deliveryType, publicID), params, &res)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.Error.Message != "" {
+			result.Error = res.Error
+			return result, nil
+		}
+
+		result.Derived = append(result.Derived, res.Derived...)
+
+		if res.DerivedNextCursor == "" {
+			return result, nil
+		}
+
+		params.DerivedNextCursor = res.DerivedNextCursor
+	}
+}
+
+// eagerTransformation is a transformation generated by the explicit call,
+// with the format of the derived resource. An empty format keeps the format
+// of the original asset.
+type eagerTransformation struct {
+	Format         string
+	Transformation string
+}
+
+// String returns the transformation in the syntax of the eager parameter,
+// where the format is appended as an extension.
+func (e eagerTransformation) String() string {
+	if e.Format == "" {
+		return e.Transformation
+	}
+
+	return e.Transformation + "/" + e.Format
+}
+
+// matches reports whether the derived resource was generated by the eager
+// transformation. Cloudinary returns the format either as the extension of
+// the transformation or only as the format of the derived resource.
+func (e eagerTransformation) matches(d derivedResource) bool {
+	transformation := d.Transformation
+
+	if e.Format != "" {
+		if d.Format != e.Format {
+			return false
+		}
+		transformation = strings.TrimSuffix(transformation, "/"+e.Format)
+	}
+
+	return transformationEqual(transformation, e.Transformation)
+}
+
+// matchDerivedResources returns the derived resource of each eager
+// transformation, in the same order, and the transformations which have no
+// derived resource yet.
+func matchDerivedResources(eager []eagerTransformation, derived []derivedResource) ([]derivedResource, []string) {
+	matched := make([]derivedResource, 0, len(eager))
+	var missing []string
+
+	for _, e := range eager {
+		found := false
+
+		for _, d := range derived {
+			if e.matches(d) {
+				matched = append(matched, d)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, e.String())
+		}
+	}
+
+	return matched, missing
+}
+
+// explicitEagerResult is the result of the explicit call.
+type explicitEagerResult struct {
+	PublicID string        `json:"public_id"`
+	Status   string        `json:"status"`
+	Error    api.ErrorResp `json:"error,omitempty"`
+}
+
+// generateDerivedResources generates the eager transformations of an asset
+// with the explicit call. When async is set, Cloudinary processes them in the
+// background and the call returns before the derived resources exist.
+func generateDerivedResources(ctx context.Context, client *cloudinary.Cloudinary, resourceType, deliveryType, publicID string, eager []eagerTransformation, async bool) (*explicitEagerResult, error) {
+	transformations := make([]string, len(eager))
+	for i, e := range eager {
+		transformations[i] = e.String()
+	}
+
+	params := url.Values{}
+	params.Set("eager", strings.Join(transformations, "|"))
+	params.Set("public_id", publicID)
+	params.Set("type", deliveryType)
+	if async {
+		params.Set("eager_async", "true")
+	}
+
+	var res explicitEagerResult
+
+	if err := callUploadAPI(ctx, client, api.BuildPath(resourceType, "explicit"), params, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// findDerivedResources checks the asset details once and returns the derived
+// resource of each eager transformation, in the same order. It fails when any
+// transformation has no derived resource.
+func findDerivedResources(ctx context.Context, client *cloudinary.Cloudinary, resourceType, deliveryType, publicID string, eager []eagerTransformation) ([]derivedResource, error) {
+	res, err := getDerivedResources(ctx, client, resourceType, deliveryType, publicID)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Error.Message != "" {
+		return nil, errors.New(res.Error.Message)
+	}
+
+	matched, missing := matchDerivedResources(eager, res.Derived)
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no derived resource found for %s", strings.Join(missing, ", "))
+	}
+
+	return matched, nil
+}
+
+// waitForDerivedResources polls the asset details until every eager
+// transformation has a derived resource, and returns them in the same order.
+// It gives up when the context is done.
+func waitForDerivedResources(ctx context.Context, client *cloudinary.Cloudinary, resourceType, deliveryType, publicID string, eager []eagerTransformation) ([]derivedResource, error) {
+	for {
+		res, err := getDerivedResources(ctx, client, resourceType, deliveryType, publicID)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.Error.Message != "" {
+			return nil, errors.New(res.Error.Message)
+		}
+
+		matched, missing := matchDerivedResources(eager, res.Derived)
+		if len(missing) == 0 {
+			return matched, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for the derived resources of %s: %s", strings.Join(missing, ", "), ctx.Err())
+		case <-time.After(derivedPollInterval):
+		}
+	}
+}
+
+// deleteDerivedResources deletes derived resources by ID, keeping the
+// original assets and any other derived resource.
+func deleteDerivedResources(ctx context.Context, client *cloudinary.Cloudinary, ids []string) error {
+	for _, batch := range batchStrings(ids, maxAssetsByIDs) {
+		res, err := client.Admin.DeleteDerivedAssets(ctx, admin.DeleteDerivedAssetsParams{
+			DerivedAssetIDs: batch,
+		})
+		if err != nil {
+			return err
+		}
+
+		if res.Error.Message != "" {
+			return errors.New(res.Error.Message)
+		}
+	}
+
+	return nil
+}
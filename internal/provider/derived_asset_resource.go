@@ -0,0 +1,485 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type derivedAssetResourceType struct{}
+
+func (t derivedAssetResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	transformationSteps := transformationStepsListBlock("The eager transformations which generate the derived resources described with steps. Conflicts with `transformations`.")
+	transformationSteps.PlanModifiers = tfsdk.AttributePlanModifiers{
+		tfsdk.RequiresReplace(),
+	}
+
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Derived Asset resource. Generates derived versions of an existing asset ahead of delivery with eager transformations, and deletes the derived versions it generated when destroyed. Equivalent derived versions which already existed, e.g. generated by a delivery URL or by another `cloudinary_derived_asset`, are adopted and kept.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"derived": {
+				MarkdownDescription: "The derived resources, in the order of `transformations`.",
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"adopted": {
+							MarkdownDescription: "Whether the derived resource existed before the resource was created, in which case it is kept when the resource is destroyed.",
+							Computed:            true,
+							Type:                types.BoolType,
+						},
+						"bytes": {
+							MarkdownDescription: "The size of the derived resource in bytes.",
+							Computed:            true,
+							Type:                types.Int64Type,
+						},
+						"format": {
+							MarkdownDescription: "The format of the derived resource.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"id": {
+							MarkdownDescription: "The ID of the derived resource.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"secure_url": {
+							MarkdownDescription: "The HTTPS delivery URL of the derived resource.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"transformation": {
+							MarkdownDescription: "The transformation of the derived resource.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"url": {
+							MarkdownDescription: "The HTTP delivery URL of the derived resource.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+					},
+				),
+				Computed: true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"eager_async": {
+				MarkdownDescription: "Whether Cloudinary generates the derived resources in the background, which large videos require. The creation then waits until every derived resource exists. Defaults to `false`.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"format": {
+				MarkdownDescription: "The format of the derived resources, e.g. `webp` or `mp4`. The format of the asset is kept when omitted.",
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"id": {
+				MarkdownDescription: "The ID of the asset in the `resource_type/type/public_id` form.",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"public_id": {
+				MarkdownDescription: "The public ID of the asset.",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"resource_type": {
+				MarkdownDescription: "The resource type of the asset. One of `image` (default), `video` or `raw`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"image", "raw", "video"}},
+				},
+			},
+			"timeouts": timeoutsAttribute(),
+			"transformations": {
+				MarkdownDescription: "The eager transformations which generate the derived resources. Conflicts with `transformation_steps`.",
+				Optional:            true,
+				Type:                types.ListType{ElemType: types.StringType},
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					transformationPlanModifier{},
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					transformationValidator{},
+				},
+			},
+			"type": {
+				MarkdownDescription: "The delivery type of the asset. One of `upload` (default), `private` or `authenticated`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"authenticated", "private", "upload"}},
+				},
+			},
+		},
+
+		Blocks: map[string]tfsdk.Block{
+			"transformation_steps": transformationSteps,
+		},
+	}, nil
+}
+
+func (t derivedAssetResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return derivedAssetResource{
+		provider: provider,
+	}, diags
+}
+
+type derivedResourceData struct {
+	Adopted        types.Bool   `tfsdk:"adopted"`
+	Bytes          types.Int64  `tfsdk:"bytes"`
+	Format         types.String `tfsdk:"format"`
+	ID             types.String `tfsdk:"id"`
+	SecureURL      types.String `tfsdk:"secure_url"`
+	Transformation types.String `tfsdk:"transformation"`
+	URL            types.String `tfsdk:"url"`
+}
+
+type derivedAssetResourceData struct {
+	Derived             types.List                    `tfsdk:"derived"`
+	EagerAsync          types.Bool                    `tfsdk:"eager_async"`
+	Format              types.String                  `tfsdk:"format"`
+	ID                  types.String                  `tfsdk:"id"`
+	PublicID            types.String                  `tfsdk:"public_id"`
+	ResourceType        types.String                  `tfsdk:"resource_type"`
+	Timeouts            *timeoutsData                 `tfsdk:"timeouts"`
+	Transformations     types.List                    `tfsdk:"transformations"`
+	TransformationSteps []transformationStepsListData `tfsdk:"transformation_steps"`
+	Type                types.String                  `tfsdk:"type"`
+}
+
+// setDefaults fills the optional attributes which are not configured with
+// their default values.
+func (data *derivedAssetResourceData) setDefaults() {
+	if data.ResourceType.Null || data.ResourceType.Unknown {
+		data.ResourceType = types.String{Value: "image"}
+	}
+	if data.Type.Null || data.Type.Unknown {
+		data.Type = types.String{Value: "upload"}
+	}
+	if data.TransformationSteps == nil {
+		data.TransformationSteps = []transformationStepsListData{}
+	}
+}
+
+// eager returns the eager transformations of the resource, serializing the
+// steps when they are used instead.
+func (data derivedAssetResourceData) eager(ctx context.Context) ([]eagerTransformation, diag.Diagnostics) {
+	transformations, diags := expandTransformationList(ctx, data.Transformations, data.TransformationSteps)
+
+	eager := make([]eagerTransformation, len(transformations))
+	for i, t := range transformations {
+		eager[i] = eagerTransformation{Format: data.Format.Value, Transformation: t}
+	}
+
+	return eager, diags
+}
+
+// derivedResourceAttrTypes are the attribute types of a derived resource.
+var derivedResourceAttrTypes = map[string]attr.Type{
+	"adopted":        types.BoolType,
+	"bytes":          types.Int64Type,
+	"format":         types.StringType,
+	"id":             types.StringType,
+	"secure_url":     types.StringType,
+	"transformation": types.StringType,
+	"url":            types.StringType,
+}
+
+// flattenDerivedResources returns the list of derived resources, where the
+// adopted ones are keyed by ID.
+func flattenDerivedResources(derived []derivedResource, adopted map[string]bool) types.List {
+	list := types.List{ElemType: types.ObjectType{AttrTypes: derivedResourceAttrTypes}}
+
+	for _, d := range derived {
+		list.Elems = append(list.Elems, types.Object{
+			AttrTypes: derivedResourceAttrTypes,
+			Attrs: map[string]attr.Value{
+				"adopted":        types.Bool{Value: adopted[d.ID]},
+				"bytes":          types.Int64{Value: d.Bytes},
+				"format":         types.String{Value: d.Format},
+				"id":             types.String{Value: d.ID},
+				"secure_url":     types.String{Value: d.SecureURL},
+				"transformation": types.String{Value: d.Transformation},
+				"url":            types.String{Value: d.URL},
+			},
+		})
+	}
+
+	return list
+}
+
+type derivedAssetResource struct {
+	provider provider
+}
+
+func (r derivedAssetResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data derivedAssetResourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Transformations.Null && len(data.TransformationSteps) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("transformations"),
+			"Conflicting Attributes",
+			"Only one of transformations and transformation_steps can be set.",
+		)
+	}
+
+	if data.Transformations.Null && len(data.TransformationSteps) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("transformations"),
+			"Missing Attribute",
+			"One of transformations or transformation_steps must be set.",
+		)
+	}
+}
+
+func (r derivedAssetResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data derivedAssetResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setDefaults()
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.createTimeout())
+	defer cancel()
+
+	eager, diags := data.eager(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Derived resources which already exist are adopted instead of being
+	// deleted with the resource.
+	existing, err := getDerivedResources(ctx, r.provider.client, data.ResourceType.Value, data.Type.Value, data.PublicID.Value)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read derived assets, got error: %s", err),
+		)
+		return
+	}
+
+	if existing.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read derived assets, got error: %s", existing.Error.Message),
+		)
+		return
+	}
+
+	adopted := make(map[string]bool, len(existing.Derived))
+	for _, d := range existing.Derived {
+		adopted[d.ID] = true
+	}
+
+	res, err := generateDerivedResources(ctx, r.provider.client, data.ResourceType.Value, data.Type.Value, data.PublicID.Value, eager, data.EagerAsync.Value)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to generate derived assets, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to generate derived assets, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	// The derived resources are generated by the time a synchronous call
+	// returns, so a missing one is never going to appear.
+	var derived []derivedResource
+	if data.EagerAsync.Value {
+		derived, err = waitForDerivedResources(ctx, r.provider.client, data.ResourceType.Value, data.Type.Value, data.PublicID.Value, eager)
+	} else {
+		derived, err = findDerivedResources(ctx, r.provider.client, data.ResourceType.Value, data.Type.Value, data.PublicID.Value, eager)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to generate derived assets, got error: %s", err),
+		)
+		return
+	}
+
+	data.ID = types.String{Value: assetID(data.ResourceType.Value, data.Type.Value, data.PublicID.Value)}
+	data.Derived = flattenDerivedResources(derived, adopted)
+
+	tflog.Trace(ctx, "created a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r derivedAssetResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data derivedAssetResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := getDerivedResources(ctx, r.provider.client, data.ResourceType.Value, data.Type.Value, data.PublicID.Value)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read derived assets, got error: %s", err),
+		)
+		return
+	}
+
+	if isNotFoundError(res.Error.Message) {
+		tflog.Warn(ctx, "asset not found, removing the derived assets from the state", map[string]interface{}{
+			"id": data.ID.Value,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read derived assets, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	eager, diags := data.eager(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Derived resources deleted outside of Terraform, e.g. by an
+	// invalidation, are generated again.
+	derived, missing := matchDerivedResources(eager, res.Derived)
+	if len(missing) > 0 {
+		tflog.Warn(ctx, "derived assets not found, removing them from the state", map[string]interface{}{
+			"id":              data.ID.Value,
+			"transformations": missing,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	var current []derivedResourceData
+
+	resp.Diagnostics.Append(data.Derived.ElementsAs(ctx, &current, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	adopted := make(map[string]bool, len(current))
+	for _, d := range current {
+		adopted[d.ID.Value] = d.Adopted.Value
+	}
+
+	data.Derived = flattenDerivedResources(derived, adopted)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r derivedAssetResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data derivedAssetResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setDefaults()
+
+	// Only eager_async and the timeouts can change in place, and both only
+	// matter when the derived resources are generated.
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r derivedAssetResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data derivedAssetResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var derived []derivedResourceData
+
+	resp.Diagnostics.Append(data.Derived.ElementsAs(ctx, &derived, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Adopted derived resources existed before the resource and are kept.
+	var ids []string
+	for _, d := range derived {
+		if !d.Adopted.Value {
+			ids = append(ids, d.ID.Value)
+		}
+	}
+
+	if err := deleteDerivedResources(ctx, r.provider.client, ids); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete derived assets, got error: %s", err),
+		)
+		return
+	}
+}
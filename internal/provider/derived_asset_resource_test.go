@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDerivedAssetResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccDerivedAssetResourceConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_derived_asset.test", "id", "image/upload/terraform_acc_test/derived"),
+					resource.TestCheckResourceAttr("cloudinary_derived_asset.test", "derived.#", "2"),
+					resource.TestCheckResourceAttr("cloudinary_derived_asset.test", "derived.0.format", "webp"),
+					resource.TestCheckResourceAttrSet("cloudinary_derived_asset.test", "derived.1.secure_url"),
+					resource.TestCheckResourceAttr("cloudinary_derived_asset.test", "derived.0.adopted", "false"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccDerivedAssetResourceConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_derived_asset.test", "eager_async", "true"),
+					resource.TestCheckResourceAttr("cloudinary_derived_asset.test", "derived.#", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccDerivedAssetResourceConfig(async bool) string {
+	return fmt.Sprintf(`
+resource "cloudinary_asset" "test" {
+  content_base64 = %[1]q
+  public_id      = "terraform_acc_test/derived"
+}
+
+resource "cloudinary_derived_asset" "test" {
+  public_id       = cloudinary_asset.test.public_id
+  transformations = ["c_fill,h_100,w_100", "e_grayscale,w_200"]
+  format          = "webp"
+  eager_async     = %[2]t
+
+  timeouts = {
+    create = "5m"
+  }
+}
+`, testAccRedPixel, async)
+}
+
+func TestAccDerivedAssetResource_steps(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "cloudinary_asset" "test" {
+  content_base64 = %[1]q
+  public_id      = "terraform_acc_test/derived_steps"
+}
+
+resource "cloudinary_derived_asset" "test" {
+  public_id = cloudinary_asset.test.public_id
+
+  transformation_steps {
+    step {
+      crop   = "fill"
+      width  = "100"
+      height = "100"
+    }
+
+    step {
+      effect = "grayscale"
+    }
+  }
+}
+`, testAccRedPixel),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_derived_asset.test", "derived.#", "1"),
+					resource.TestCheckResourceAttr("cloudinary_derived_asset.test", "transformation_steps.0.step.#", "2"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type derivedResourcesDataSourceType struct{}
+
+func (t derivedResourcesDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Derived resources data source. Lists the derived resources of an asset and their sizes.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"derived_resources": {
+				MarkdownDescription: "The derived resources of the asset.",
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"bytes": {
+							MarkdownDescription: "The size of the derived resource in bytes.",
+							Computed:            true,
+							Type:                types.Int64Type,
+						},
+						"format": {
+							MarkdownDescription: "The format of the derived resource.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"id": {
+							MarkdownDescription: "The ID of the derived resource.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"secure_url": {
+							MarkdownDescription: "The HTTPS delivery URL of the derived resource.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"transformation": {
+							MarkdownDescription: "The transformation of the derived resource.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"url": {
+							MarkdownDescription: "The HTTP delivery URL of the derived resource.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+					},
+				),
+				Computed: true,
+			},
+			"id": {
+				MarkdownDescription: "The ID of the asset in the `resource_type/type/public_id` form.",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"public_id": {
+				MarkdownDescription: "The public ID of the asset.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"resource_type": {
+				MarkdownDescription: "The resource type of the asset. One of `image` (default), `video` or `raw`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"image", "raw", "video"}},
+				},
+			},
+			"total_bytes": {
+				MarkdownDescription: "The total size of the listed derived resources in bytes.",
+				Computed:            true,
+				Type:                types.Int64Type,
+			},
+			"transformation_prefix": {
+				MarkdownDescription: "Only list the derived resources whose transformation starts with this prefix, e.g. `sp_`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"type": {
+				MarkdownDescription: "The delivery type of the asset. One of `upload` (default), `private` or `authenticated`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"authenticated", "private", "upload"}},
+				},
+			},
+		},
+	}, nil
+}
+
+func (t derivedResourcesDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return derivedResourcesDataSource{
+		provider: provider,
+	}, diags
+}
+
+type derivedResourcesDataSourceData struct {
+	DerivedResources     []derivedResourceData `tfsdk:"derived_resources"`
+	ID                   types.String          `tfsdk:"id"`
+	PublicID             types.String          `tfsdk:"public_id"`
+	ResourceType         types.String          `tfsdk:"resource_type"`
+	TotalBytes           types.Int64           `tfsdk:"total_bytes"`
+	TransformationPrefix types.String          `tfsdk:"transformation_prefix"`
+	Type                 types.String          `tfsdk:"type"`
+}
+
+// filterDerivedResources returns the derived resources whose transformation
+// starts with the prefix.
+func filterDerivedResources(derived []derivedResource, prefix string) []derivedResource {
+	filtered := []derivedResource{}
+
+	for _, d := range derived {
+		if strings.HasPrefix(d.Transformation, prefix) {
+			filtered = append(filtered, d)
+		}
+	}
+
+	return filtered
+}
+
+type derivedResourcesDataSource struct {
+	provider provider
+}
+
+func (d derivedResourcesDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data derivedResourcesDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ResourceType.Null {
+		data.ResourceType = types.String{Value: "image"}
+	}
+	if data.Type.Null {
+		data.Type = types.String{Value: "upload"}
+	}
+
+	data.ID = types.String{Value: assetID(data.ResourceType.Value, data.Type.Value, data.PublicID.Value)}
+
+	res, err := getDerivedResources(ctx, d.provider.client, data.ResourceType.Value, data.Type.Value, data.PublicID.Value)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read derived resources, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read derived resources, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	var total int64
+
+	data.DerivedResources = []derivedResourceData{}
+	for _, r := range filterDerivedResources(res.Derived, data.TransformationPrefix.Value) {
+		data.DerivedResources = append(data.DerivedResources, derivedResourceData{
+			Bytes:          types.Int64{Value: r.Bytes},
+			Format:         types.String{Value: r.Format},
+			ID:             types.String{Value: r.ID},
+			SecureURL:      types.String{Value: r.SecureURL},
+			Transformation: types.String{Value: r.Transformation},
+			URL:            types.String{Value: r.URL},
+		})
+		total += r.Bytes
+	}
+
+	data.TotalBytes = types.Int64{Value: total}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDerivedResourcesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccDerivedResourcesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudinary_derived_resources.test", "id", "image/upload/terraform_acc_test/derived_resources"),
+					resource.TestCheckResourceAttr("data.cloudinary_derived_resources.test", "derived_resources.#", "1"),
+					resource.TestCheckResourceAttrPair("data.cloudinary_derived_resources.test", "derived_resources.0.id", "cloudinary_derived_asset.test", "derived.1.id"),
+					resource.TestCheckResourceAttrPair("data.cloudinary_derived_resources.test", "total_bytes", "cloudinary_derived_asset.test", "derived.1.bytes"),
+				),
+			},
+		},
+	})
+}
+
+var testAccDerivedResourcesDataSourceConfig = fmt.Sprintf(`
+resource "cloudinary_asset" "test" {
+  content_base64 = %[1]q
+  public_id      = "terraform_acc_test/derived_resources"
+}
+
+resource "cloudinary_derived_asset" "test" {
+  public_id       = cloudinary_asset.test.public_id
+  transformations = ["c_fill,h_100,w_100", "e_grayscale"]
+}
+
+data "cloudinary_derived_resources" "test" {
+  public_id             = cloudinary_derived_asset.test.public_id
+  transformation_prefix = "e_grayscale"
+}
+`, testAccRedPixel)
+
+func TestFilterDerivedResources(t *testing.T) {
+	derived := []derivedResource{
+		{ID: "a", Transformation: "c_fill,h_100,w_100"},
+		{ID: "b", Transformation: "sp_hd/m3u8"},
+		{ID: "c", Transformation: "sp_sd/mpd"},
+	}
+
+	tests := []struct {
+		prefix string
+		ids    []string
+	}{
+		{"", []string{"a", "b", "c"}},
+		{"sp_", []string{"b", "c"}},
+		{"sp_hd", []string{"b"}},
+		{"e_", nil},
+	}
+
+	for _, tt := range tests {
+		var ids []string
+		for _, d := range filterDerivedResources(derived, tt.prefix) {
+			ids = append(ids, d.ID)
+		}
+
+		if !reflect.DeepEqual(ids, tt.ids) {
+			t.Errorf("filterDerivedResources(%q) = %v, want %v", tt.prefix, ids, tt.ids)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go"
+)
+
+func TestMatchDerivedResources(t *testing.T) {
+	derived := []derivedResource{
+		{ID: "a", Transformation: "c_fill,h_200,w_200", Format: "jpg"},
+		{ID: "b", Transformation: "w_400/webp", Format: "webp"},
+		{ID: "c", Transformation: "sp_hd", Format: "m3u8"},
+	}
+
+	tests := []struct {
+		eager   []eagerTransformation
+		ids     []string
+		missing []string
+	}{
+		{
+			eager: []eagerTransformation{{Transformation: "w_200,h_200,c_fill"}},
+			ids:   []string{"a"},
+		},
+		{
+			eager: []eagerTransformation{{Transformation: "w_400", Format: "webp"}, {Transformation: "sp_hd", Format: "m3u8"}},
+			ids:   []string{"b", "c"},
+		},
+		{
+			eager:   []eagerTransformation{{Transformation: "w_400", Format: "avif"}, {Transformation: "sp_hd", Format: "mpd"}},
+			missing: []string{"w_400/avif", "sp_hd/mpd"},
+		},
+	}
+
+	for _, tt := range tests {
+		matched, missing := matchDerivedResources(tt.eager, derived)
+
+		var ids []string
+		for _, d := range matched {
+			ids = append(ids, d.ID)
+		}
+
+		if !reflect.DeepEqual(ids, tt.ids) {
+			t.Errorf("matchDerivedResources(%v) = %v, want %v", tt.eager, ids, tt.ids)
+		}
+
+		if !reflect.DeepEqual(missing, tt.missing) {
+			t.Errorf("matchDerivedResources(%v) missing = %v, want %v", tt.eager, missing, tt.missing)
+		}
+	}
+}
+
+func TestFindDerivedResources(t *testing.T) {
+	calls := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		fmt.Fprint(w, `{"derived":[{"id":"a","transformation":"c_fill,w_100","format":"jpg"}]}`)
+	}))
+	defer srv.Close()
+
+	client, err := cloudinary.NewFromParams("demo", "key", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Admin.Config.API.UploadPrefix = srv.URL
+
+	eager := []eagerTransformation{{Transformation: "w_100,c_fill"}}
+
+	derived, err := findDerivedResources(context.Background(), client, "image", "upload", "sample", eager)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(derived) != 1 || derived[0].ID != "a" {
+		t.Errorf("findDerivedResources() = %v, want a", derived)
+	}
+
+	eager = append(eager, eagerTransformation{Transformation: "w_200"})
+
+	calls = 0
+
+	_, err = findDerivedResources(context.Background(), client, "image", "upload", "sample", eager)
+	if err == nil || !strings.Contains(err.Error(), "w_200") {
+		t.Errorf("findDerivedResources() error = %v, want w_200 missing", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("findDerivedResources() checked %d times, want once", calls)
+	}
+}
+
+func TestWaitForDerivedResources(t *testing.T) {
+	defer func(interval time.Duration) { derivedPollInterval = interval }(derivedPollInterval)
+	derivedPollInterval = time.Millisecond
+
+	calls := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1_1/demo/resources/video/upload/movie" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+
+		calls++
+
+		switch {
+		case calls < 3:
+			fmt.Fprint(w, `{"derived":[]}`)
+		case r.URL.Query().Get("derived_next_cursor") == "":
+			fmt.Fprint(w, `{"derived":[{"id":"a","transformation":"w_400","format":"mp4"}],"derived_next_cursor":"next"}`)
+		default:
+			fmt.Fprint(w, `{"derived":[{"id":"b","transformation":"sp_hd","format":"m3u8"}]}`)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := cloudinary.NewFromParams("demo", "key", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Admin.Config.API.UploadPrefix = srv.URL
+
+	eager := []eagerTransformation{{Transformation: "sp_hd", Format: "m3u8"}, {Transformation: "w_400", Format: "mp4"}}
+
+	derived, err := waitForDerivedResources(context.Background(), client, "video", "upload", "movie", eager)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(derived) != 2 || derived[0].ID != "b" || derived[1].ID != "a" {
+		t.Errorf("waitForDerivedResources() = %v, want b and a", derived)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	eager = []eagerTransformation{{Transformation: "sp_hd", Format: "mpd"}}
+
+	if _, err := waitForDerivedResources(ctx, client, "video", "upload", "movie", eager); err == nil {
+		t.Error("waitForDerivedResources() error = nil, want a timeout")
+	}
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go"
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const (
+	// fixedFolderMode is the legacy folder mode, in which the folder of an
+	// asset is the prefix of its public ID.
+	fixedFolderMode = "fixed"
+
+	// dynamicFolderMode is the folder mode in which folders are independent
+	// of public IDs and can be renamed.
+	dynamicFolderMode = "dynamic"
+)
+
+// configParams are the parameters of the config call.
+type configParams struct {
+	Settings bool `json:"settings,omitempty"`
+}
+
+type configResult struct {
+	Settings struct {
+		FolderMode string `json:"folder_mode"`
+	} `json:"settings"`
+	Error api.ErrorResp `json:"error,omitempty"`
+}
+
+// getFolderMode returns the folder mode of the product environment. Product
+// environments which do not report a folder mode use fixed folders.
+func getFolderMode(ctx context.Context, client *cloudinary.Cloudinary) (string, error) {
+	var res configResult
+
+	err := callAdminAPI(ctx, client, http.MethodGet, "config", configParams{Settings: true}, &res)
+	if err != nil {
+		return "", err
+	}
+
+	if res.Error.Message != "" {
+		return "", fmt.Errorf("%s", res.Error.Message)
+	}
+
+	if res.Settings.FolderMode == "" {
+		return fixedFolderMode, nil
+	}
+
+	return res.Settings.FolderMode, nil
+}
+
+// warnDynamicFolderAttributes warns about the configured attributes which
+// only have an effect in dynamic folder mode when the product environment
+// uses fixed folders. The folder mode is only looked up when one of the
+// attributes is set.
+func warnDynamicFolderAttributes(ctx context.Context, client *cloudinary.Cloudinary, attrs map[string]attr.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var names []string
+	for name, v := range attrs {
+		if !v.IsNull() && !v.IsUnknown() {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return diags
+	}
+
+	mode, err := getFolderMode(ctx, client)
+	if err != nil {
+		diags.AddWarning(
+			"Unable to Read Folder Mode",
+			fmt.Sprintf("Unable to check the attributes which depend on the folder mode, got error: %s", err),
+		)
+		return diags
+	}
+
+	if mode == dynamicFolderMode {
+		return diags
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		diags.AddAttributeWarning(
+			path.Root(name),
+			"Attribute Ignored in Fixed Folder Mode",
+			fmt.Sprintf("The product environment uses fixed folders, so %s has no effect. It is only supported in dynamic folder mode.", name),
+		)
+	}
+
+	return diags
+}
+
+// searchEscaper escapes the characters with a special meaning in search
+// expressions.
+var searchEscaper = strings.NewReplacer(
+	`\`, `\\`, `"`, `\"`, ` `, `\ `, `(`, `\(`, `)`, `\)`, `:`, `\:`,
+	`[`, `\[`, `]`, `\]`, `{`, `\{`, `}`, `\}`, `!`, `\!`, `^`, `\^`,
+	`~`, `\~`, `*`, `\*`, `?`, `\?`, `&`, `\&`, `|`, `\|`, `+`, `\+`,
+	`-`, `\-`, `=`, `\=`, `<`, `\<`, `>`, `\>`,
+)
+
+// folderSearchExpression returns the search expression matching the assets
+// of a folder and its subfolders in the given folder mode.
+func folderSearchExpression(folder string, mode string) string {
+	field := "folder"
+	if mode == dynamicFolderMode {
+		field = "asset_folder"
+	}
+
+	escaped := searchEscaper.Replace(folder)
+
+	return fmt.Sprintf("%[1]s:%[2]s OR %[1]s:%[2]s/*", field, escaped)
+}
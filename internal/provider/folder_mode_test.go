@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFolderSearchExpression(t *testing.T) {
+	tests := []struct {
+		folder string
+		mode   string
+		want   string
+	}{
+		{"products", fixedFolderMode, `folder:products OR folder:products/*`},
+		{"products/shoes", dynamicFolderMode, `asset_folder:products/shoes OR asset_folder:products/shoes/*`},
+		{"summer sale (2022)", fixedFolderMode, `folder:summer\ sale\ \(2022\) OR folder:summer\ sale\ \(2022\)/*`},
+	}
+
+	for _, tt := range tests {
+		if got := folderSearchExpression(tt.folder, tt.mode); got != tt.want {
+			t.Errorf("folderSearchExpression(%q, %q) = %q, want %q", tt.folder, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestWarnDynamicFolderAttributes(t *testing.T) {
+	var (
+		mode     string
+		requests int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, `{"settings":{"folder_mode":%q}}`, mode)
+	}))
+	defer srv.Close()
+
+	client, err := cloudinary.NewFromParams("demo", "key", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Admin.Config.API.UploadPrefix = srv.URL
+
+	tests := []struct {
+		mode     string
+		attrs    map[string]attr.Value
+		warnings int
+		requests int
+	}{
+		{fixedFolderMode, map[string]attr.Value{"asset_folder": types.String{Null: true}}, 0, 0},
+		{fixedFolderMode, map[string]attr.Value{"asset_folder": types.String{Unknown: true}}, 0, 0},
+		{fixedFolderMode, map[string]attr.Value{"asset_folder": types.String{Value: "products"}, "display_name": types.String{Value: "Logo"}}, 2, 1},
+		{"", map[string]attr.Value{"use_asset_folder_as_public_id_prefix": types.Bool{Value: true}}, 1, 1},
+		{dynamicFolderMode, map[string]attr.Value{"asset_folder": types.String{Value: "products"}}, 0, 1},
+	}
+
+	for _, tt := range tests {
+		mode = tt.mode
+		requests = 0
+
+		diags := warnDynamicFolderAttributes(context.Background(), client, tt.attrs)
+
+		if got := diags.WarningsCount(); got != tt.warnings {
+			t.Errorf("warnDynamicFolderAttributes(%v) in %q mode returned %d warnings, want %d", tt.attrs, tt.mode, got, tt.warnings)
+		}
+
+		if requests != tt.requests {
+			t.Errorf("warnDynamicFolderAttributes(%v) sent %d requests, want %d", tt.attrs, requests, tt.requests)
+		}
+	}
+}
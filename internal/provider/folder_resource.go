@@ -0,0 +1,440 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	pathpkg "path"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/cloudinary/cloudinary-go/api/admin"
+	"github.com/cloudinary/cloudinary-go/api/admin/search"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type folderResourceType struct{}
+
+func (t folderResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Folder resource. In dynamic folder mode, changing the path renames the folder; in fixed folder mode, it replaces the folder.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"force_destroy": {
+				MarkdownDescription: "Whether to delete the assets of the folder and its subfolders when the folder is destroyed. Folders which are not empty cannot be destroyed otherwise.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"name": {
+				MarkdownDescription: "The name of the folder, the last segment of the path.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"path": {
+				MarkdownDescription: "The full path of the folder.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t folderResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return folderResource{
+		provider: provider,
+	}, diags
+}
+
+type folderResourceData struct {
+	ForceDestroy types.Bool   `tfsdk:"force_destroy"`
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Path         types.String `tfsdk:"path"`
+}
+
+// renameFolderParams are the parameters of the rename folder call.
+type renameFolderParams struct {
+	ToFolder string `json:"to_folder"`
+}
+
+type renameFolderResult struct {
+	From  admin.FolderResult `json:"from"`
+	To    admin.FolderResult `json:"to"`
+	Error api.ErrorResp      `json:"error,omitempty"`
+}
+
+type folderResource struct {
+	provider provider
+}
+
+func (r folderResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan folderResourceData
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Path.Unknown {
+		plan.ID = plan.Path
+		plan.Name = types.String{Value: pathpkg.Base(plan.Path.Value)}
+
+		diags = resp.Plan.Set(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state folderResourceData
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || plan.Path.Equal(state.Path) {
+		return
+	}
+
+	// Folders can only be renamed in dynamic folder mode.
+	mode, err := getFolderMode(ctx, r.provider.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read folder mode, got error: %s", err),
+		)
+		return
+	}
+
+	if mode != dynamicFolderMode {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("path"))
+	}
+}
+
+func (r folderResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data folderResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := admin.CreateFolderParams{
+		Folder: data.Path.Value,
+	}
+
+	res, err := r.provider.client.Admin.CreateFolder(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create folder, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create folder, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	data.ID = data.Path
+	data.Name = types.String{Value: pathpkg.Base(data.Path.Value)}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r folderResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data folderResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	folder, diags := r.find(ctx, data.Path.Value)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if folder == nil {
+		tflog.Warn(ctx, "folder not found, removing it from the state", map[string]interface{}{
+			"path": data.Path.Value,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.String{Value: folder.Path}
+	data.Name = types.String{Value: folder.Name}
+	data.Path = types.String{Value: folder.Path}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r folderResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, state folderResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Path.Equal(state.Path) {
+		params := renameFolderParams{
+			ToFolder: data.Path.Value,
+		}
+
+		var res renameFolderResult
+
+		err := callAdminAPI(ctx, r.provider.client, http.MethodPut, api.BuildPath("folders", state.Path.Value), params, &res)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to rename folder, got error: %s", err),
+			)
+			return
+		}
+
+		if res.Error.Message != "" {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to rename folder, got error: %s", res.Error.Message),
+			)
+			return
+		}
+	}
+
+	data.ID = data.Path
+	data.Name = types.String{Value: pathpkg.Base(data.Path.Value)}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r folderResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data folderResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mode, err := getFolderMode(ctx, r.provider.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read folder mode, got error: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.deleteAssets(ctx, data.Path.Value, mode, data.ForceDestroy.Value)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := admin.DeleteFolderParams{
+		Folder: data.Path.Value,
+	}
+
+	res, err := r.provider.client.Admin.DeleteFolder(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete folder, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete folder, got error: %s", res.Error.Message),
+		)
+		return
+	}
+}
+
+func (r folderResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, path.Root("path"), req, resp)
+}
+
+// find returns the folder with the given path, or nil when it does not
+// exist. Folders are looked up in the subfolders of their parent, since
+// listing a missing folder is an error.
+func (r folderResource) find(ctx context.Context, folder string) (*admin.FolderResult, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	parent := pathpkg.Dir(folder)
+
+	var cursor string
+	for {
+		var (
+			res *admin.FoldersResult
+			err error
+		)
+
+		if parent == "." {
+			res, err = r.provider.client.Admin.RootFolders(ctx, admin.RootFoldersParams{MaxResults: 500, NextCursor: cursor})
+		} else {
+			res, err = r.provider.client.Admin.SubFolders(ctx, admin.SubFoldersParams{Folder: parent, MaxResults: 500, NextCursor: cursor})
+		}
+
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to read folder, got error: %s", err),
+			)
+			return nil, diags
+		}
+
+		// The parent folder does not exist either.
+		if res.Error.Message != "" {
+			return nil, diags
+		}
+
+		for i, f := range res.Folders {
+			if f.Path == folder {
+				return &res.Folders[i], diags
+			}
+		}
+
+		if res.NextCursor == "" {
+			return nil, diags
+		}
+		cursor = res.NextCursor
+	}
+}
+
+// deleteAssets deletes the assets of the folder and its subfolders. Unless
+// force is set, it fails when the folder contains any asset.
+func (r folderResource) deleteAssets(ctx context.Context, folder string, mode string, force bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	query := search.Query{
+		Expression: folderSearchExpression(folder, mode),
+		MaxResults: 500,
+	}
+
+	// Assets are deleted by resource type and delivery type.
+	groups := map[[2]string][]string{}
+	count := 0
+
+	for {
+		res, err := r.provider.client.Admin.Search(ctx, query)
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to search folder assets, got error: %s", err),
+			)
+			return diags
+		}
+
+		if res.Error.Message != "" {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to search folder assets, got error: %s", res.Error.Message),
+			)
+			return diags
+		}
+
+		if len(res.Assets) > 0 && !force {
+			diags.AddError(
+				"Folder Not Empty",
+				fmt.Sprintf("The folder %q contains %d assets. Delete them first or set force_destroy to delete them with the folder.", folder, res.TotalCount),
+			)
+			return diags
+		}
+
+		for _, a := range res.Assets {
+			key := [2]string{a.ResourceType, a.Type}
+			groups[key] = append(groups[key], a.PublicID)
+			count++
+		}
+
+		if res.NextCursor == "" {
+			break
+		}
+		query.NextCursor = res.NextCursor
+	}
+
+	for key, publicIDs := range groups {
+		// Up to 100 assets can be deleted at once.
+		for len(publicIDs) > 0 {
+			n := len(publicIDs)
+			if n > 100 {
+				n = 100
+			}
+
+			params := admin.DeleteAssetsParams{
+				AssetType:    api.AssetType(key[0]),
+				DeliveryType: api.DeliveryType(key[1]),
+				PublicIDs:    publicIDs[:n],
+			}
+			publicIDs = publicIDs[n:]
+
+			res, err := r.provider.client.Admin.DeleteAssets(ctx, params)
+			if err != nil {
+				diags.AddError(
+					"Client Error",
+					fmt.Sprintf("Unable to delete folder assets, got error: %s", err),
+				)
+				return diags
+			}
+
+			if res.Error.Message != "" {
+				diags.AddError(
+					"Client Error",
+					fmt.Sprintf("Unable to delete folder assets, got error: %s", res.Error.Message),
+				)
+				return diags
+			}
+		}
+	}
+
+	if count > 0 {
+		tflog.Debug(ctx, "deleted folder assets", map[string]interface{}{
+			"folder": folder,
+			"count":  count,
+		})
+	}
+
+	return diags
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccFolderResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccFolderResourceConfig("terraform_acc_test/one"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_folder.test", "path", "terraform_acc_test/one"),
+					resource.TestCheckResourceAttr("cloudinary_folder.test", "name", "one"),
+					resource.TestCheckResourceAttr("cloudinary_folder.test", "id", "terraform_acc_test/one"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "cloudinary_folder.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force_destroy"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccFolderResourceConfig("terraform_acc_test/two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_folder.test", "path", "terraform_acc_test/two"),
+					resource.TestCheckResourceAttr("cloudinary_folder.test", "name", "two"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccFolderResourceConfig(path string) string {
+	return fmt.Sprintf(`
+resource "cloudinary_folder" "test" {
+  path          = %[1]q
+  force_destroy = true
+}
+`, path)
+}
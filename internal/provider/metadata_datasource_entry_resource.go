@@ -0,0 +1,378 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/cloudinary/cloudinary-go/api/admin"
+	"github.com/cloudinary/cloudinary-go/api/admin/metadata"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type metadataDatasourceEntryResourceType struct{}
+
+func (t metadataDatasourceEntryResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Metadata Datasource Entry resource. Manages a single value of the datasource of an `enum` or `set` metadata field, which must not also be managed with the `datasource` block of `cloudinary_metadata_field`.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"external_id": {
+				MarkdownDescription: "The external ID of the entry. Generated when omitted.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"field_external_id": {
+				MarkdownDescription: "The external ID of the metadata field.",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"value": {
+				MarkdownDescription: "The value of the entry.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t metadataDatasourceEntryResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return metadataDatasourceEntryResource{
+		provider: provider,
+	}, diags
+}
+
+type metadataDatasourceEntryResourceData struct {
+	ExternalID      types.String `tfsdk:"external_id"`
+	FieldExternalID types.String `tfsdk:"field_external_id"`
+	ID              types.String `tfsdk:"id"`
+	Value           types.String `tfsdk:"value"`
+}
+
+type metadataDatasourceEntryResource struct {
+	provider provider
+}
+
+func (r metadataDatasourceEntryResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data metadataDatasourceEntryResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := r.datasource(ctx, data.FieldExternalID.Value)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The update datasource call would silently take over an existing entry
+	// with the same external ID, or add a second entry with the same value.
+	if conflict := datasourceEntryConflict(current, data); conflict != "" {
+		resp.Diagnostics.AddError(
+			"Metadata Datasource Entry Already Exists",
+			fmt.Sprintf("%s To manage it with Terraform, import it instead.", conflict),
+		)
+		return
+	}
+
+	res, diags := r.update(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Cloudinary generates the external ID when it is omitted, the new entry
+	// is the one with the value which was not in the datasource before.
+	// Entries created in parallel for the same field have other values.
+	if data.ExternalID.Unknown || data.ExternalID.Null {
+		externalID, ok := newDatasourceEntry(current, res.Values, data.Value.Value)
+		if !ok {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to find the created metadata datasource entry %q.", data.Value.Value),
+			)
+			return
+		}
+
+		data.ExternalID = types.String{Value: externalID}
+	}
+
+	// Adding an entry which was deleted before leaves it inactive until it
+	// is restored.
+	for _, v := range res.Values {
+		if v.ExternalID != data.ExternalID.Value || v.State != "inactive" {
+			continue
+		}
+
+		params := admin.RestoreDatasourceEntriesParams{
+			FieldExternalID:    data.FieldExternalID.Value,
+			EntriesExternalIDs: []string{v.ExternalID},
+		}
+
+		res, err := r.provider.client.Admin.RestoreDatasourceEntries(ctx, params)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to restore metadata datasource entry, got error: %s", err),
+			)
+			return
+		}
+
+		if res.Error.Message != "" {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to restore metadata datasource entry, got error: %s", res.Error.Message),
+			)
+			return
+		}
+	}
+
+	data.ID = types.String{Value: data.FieldExternalID.Value + "/" + data.ExternalID.Value}
+
+	tflog.Trace(ctx, "created a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r metadataDatasourceEntryResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data metadataDatasourceEntryResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	values, diags := r.datasource(ctx, data.FieldExternalID.Value)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var entry *metadata.DataSourceValue
+	for i, v := range values {
+		if v.ExternalID == data.ExternalID.Value {
+			entry = &values[i]
+			break
+		}
+	}
+
+	// Deleted entries stay in the datasource as inactive entries.
+	if entry == nil || entry.State == "inactive" {
+		tflog.Warn(ctx, "metadata datasource entry not found, removing it from the state", map[string]interface{}{
+			"id": data.ID.Value,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.String{Value: data.FieldExternalID.Value + "/" + data.ExternalID.Value}
+	data.Value = types.String{Value: entry.Value}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r metadataDatasourceEntryResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data metadataDatasourceEntryResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags = r.update(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r metadataDatasourceEntryResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data metadataDatasourceEntryResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := admin.DeleteDataSourceEntriesParams{
+		FieldExternalID:    data.FieldExternalID.Value,
+		EntriesExternalIDs: []string{data.ExternalID.Value},
+	}
+
+	res, err := r.provider.client.Admin.DeleteDataSourceEntries(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete metadata datasource entry, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete metadata datasource entry, got error: %s", res.Error.Message),
+		)
+		return
+	}
+}
+
+func (r metadataDatasourceEntryResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	parts := strings.SplitN(req.ID, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: field_external_id/external_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("field_external_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("external_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// datasource returns every entry of the datasource of the metadata field,
+// including the inactive ones.
+func (r metadataDatasourceEntryResource) datasource(ctx context.Context, fieldExternalID string) ([]metadata.DataSourceValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	params := admin.MetadataFieldByFieldIDParams{
+		FieldExternalID: fieldExternalID,
+	}
+
+	res, err := r.provider.client.Admin.MetadataFieldByFieldID(ctx, params)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read metadata field, got error: %s", err),
+		)
+		return nil, diags
+	}
+
+	if res.Error.Message != "" {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read metadata field, got error: %s", res.Error.Message),
+		)
+		return nil, diags
+	}
+
+	return res.DataSource.Values, diags
+}
+
+// datasourceEntryConflict describes the active entry of the datasource which
+// already has the external ID or the value of the entry, or returns an empty
+// string. Inactive entries are deleted ones, which are restored on creation.
+func datasourceEntryConflict(values []metadata.DataSourceValue, data metadataDatasourceEntryResourceData) string {
+	for _, v := range values {
+		if v.State == "inactive" {
+			continue
+		}
+
+		if !data.ExternalID.Null && !data.ExternalID.Unknown && v.ExternalID == data.ExternalID.Value {
+			return fmt.Sprintf("The metadata datasource entry %q already exists in the field %q.", v.ExternalID, data.FieldExternalID.Value)
+		}
+
+		if v.Value == data.Value.Value {
+			return fmt.Sprintf("The value %q is already used by the metadata datasource entry %q of the field %q.", v.Value, v.ExternalID, data.FieldExternalID.Value)
+		}
+	}
+
+	return ""
+}
+
+// newDatasourceEntry returns the external ID of the entry of the updated
+// datasource with the value which is not in the previous one.
+func newDatasourceEntry(before, after []metadata.DataSourceValue, value string) (string, bool) {
+	previous := make(map[string]bool, len(before))
+	for _, v := range before {
+		previous[v.ExternalID] = true
+	}
+
+	for _, v := range after {
+		if !previous[v.ExternalID] && v.Value == value {
+			return v.ExternalID, true
+		}
+	}
+
+	return "", false
+}
+
+// update adds or updates the entry with the update datasource call, which
+// leaves the other entries of the datasource untouched.
+func (r metadataDatasourceEntryResource) update(ctx context.Context, data metadataDatasourceEntryResourceData) (*admin.UpdateMetadataFieldDataSourceResult, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	value := metadataDatasourceValue{
+		Value: data.Value.Value,
+	}
+	if !data.ExternalID.Null && !data.ExternalID.Unknown {
+		value.ExternalID = data.ExternalID.Value
+	}
+
+	params := metadataDatasourceParams{
+		Values: []metadataDatasourceValue{value},
+	}
+
+	var res admin.UpdateMetadataFieldDataSourceResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodPut, api.BuildPath("metadata_fields", data.FieldExternalID.Value, "datasource"), params, &res)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update metadata datasource entry, got error: %s", err),
+		)
+		return nil, diags
+	}
+
+	if res.Error.Message != "" {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update metadata datasource entry, got error: %s", res.Error.Message),
+		)
+		return nil, diags
+	}
+
+	return &res, diags
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/api/admin/metadata"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMetadataDatasourceEntryResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccMetadataDatasourceEntryResourceConfig("Blue"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_metadata_datasource_entry.test", "field_external_id", "terraform_acc_test_entries"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_datasource_entry.test", "external_id", "blue"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_datasource_entry.test", "value", "Blue"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_datasource_entry.test", "id", "terraform_acc_test_entries/blue"),
+					resource.TestCheckResourceAttrSet("cloudinary_metadata_datasource_entry.generated", "external_id"),
+					resource.TestCheckResourceAttrSet("cloudinary_metadata_datasource_entry.generated_parallel", "external_id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "cloudinary_metadata_datasource_entry.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccMetadataDatasourceEntryResourceConfig("Navy Blue"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_metadata_datasource_entry.test", "value", "Navy Blue"),
+				),
+			},
+			// Duplicate value testing
+			{
+				Config:      testAccMetadataDatasourceEntryResourceConfig("Navy Blue") + testAccMetadataDatasourceEntryResourceDuplicateConfig,
+				ExpectError: regexp.MustCompile("Metadata Datasource Entry Already Exists"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccMetadataDatasourceEntryResourceConfig(value string) string {
+	return fmt.Sprintf(`
+resource "cloudinary_metadata_field" "test" {
+  external_id = "terraform_acc_test_entries"
+  type        = "enum"
+  label       = "Terraform Acceptance Test"
+}
+
+resource "cloudinary_metadata_datasource_entry" "test" {
+  field_external_id = cloudinary_metadata_field.test.external_id
+  external_id       = "blue"
+  value             = %[1]q
+}
+
+resource "cloudinary_metadata_datasource_entry" "generated" {
+  field_external_id = cloudinary_metadata_field.test.external_id
+  value             = "Yellow"
+}
+
+resource "cloudinary_metadata_datasource_entry" "generated_parallel" {
+  field_external_id = cloudinary_metadata_field.test.external_id
+  value             = "Green"
+}
+`, value)
+}
+
+const testAccMetadataDatasourceEntryResourceDuplicateConfig = `
+resource "cloudinary_metadata_datasource_entry" "duplicate" {
+  field_external_id = cloudinary_metadata_field.test.external_id
+  value             = "Yellow"
+}
+`
+
+func TestDatasourceEntryConflict(t *testing.T) {
+	values := []metadata.DataSourceValue{
+		{ExternalID: "blue", Value: "Blue"},
+		{ExternalID: "red", Value: "Red", State: "inactive"},
+	}
+
+	tests := []struct {
+		name       string
+		externalID types.String
+		value      string
+		conflict   bool
+	}{
+		{name: "new", externalID: types.String{Value: "green"}, value: "Green"},
+		{name: "external ID taken", externalID: types.String{Value: "blue"}, value: "Navy Blue", conflict: true},
+		{name: "value taken", externalID: types.String{Value: "navy"}, value: "Blue", conflict: true},
+		{name: "generated external ID with value taken", externalID: types.String{Unknown: true}, value: "Blue", conflict: true},
+		{name: "inactive external ID", externalID: types.String{Value: "red"}, value: "Red"},
+		{name: "generated external ID with inactive value", externalID: types.String{Unknown: true}, value: "Red"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := metadataDatasourceEntryResourceData{
+				ExternalID:      tt.externalID,
+				FieldExternalID: types.String{Value: "colors"},
+				Value:           types.String{Value: tt.value},
+			}
+
+			if got := datasourceEntryConflict(values, data); (got != "") != tt.conflict {
+				t.Errorf("datasourceEntryConflict() = %q, want conflict %v", got, tt.conflict)
+			}
+		})
+	}
+}
+
+func TestNewDatasourceEntry(t *testing.T) {
+	before := []metadata.DataSourceValue{
+		{ExternalID: "blue", Value: "Blue"},
+		{ExternalID: "yellow", Value: "Yellow", State: "inactive"},
+	}
+
+	// An entry created in parallel is also new.
+	after := append(before,
+		metadata.DataSourceValue{ExternalID: "gen1", Value: "Green"},
+		metadata.DataSourceValue{ExternalID: "gen2", Value: "Yellow"},
+	)
+
+	got, ok := newDatasourceEntry(before, after, "Yellow")
+	if !ok || got != "gen2" {
+		t.Errorf("newDatasourceEntry() = %q, %v, want %q, true", got, ok, "gen2")
+	}
+
+	if _, ok := newDatasourceEntry(before, before, "Yellow"); ok {
+		t.Error("newDatasourceEntry() found an entry in an unchanged datasource")
+	}
+}
@@ -0,0 +1,933 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/cloudinary/cloudinary-go/api/admin"
+	"github.com/cloudinary/cloudinary-go/api/admin/metadata"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// metadataFieldTypes are the types of structured metadata fields.
+var metadataFieldTypes = []string{"date", "enum", "integer", "set", "string"}
+
+// metadataValidationTypes are the types of validation rules.
+var metadataValidationTypes = []string{"and", "greater_than", "less_than", "regex", "strlen"}
+
+type metadataFieldResourceType struct{}
+
+// metadataValidationRuleAttributes returns the attributes shared by the
+// validation block and the rules combined by an `and` validation.
+func metadataValidationRuleAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"equals": {
+			MarkdownDescription: "Whether the `greater_than` and `less_than` comparisons include the value itself.",
+			Optional:            true,
+			Type:                types.BoolType,
+		},
+		"max": {
+			MarkdownDescription: "The maximum length of the value for the `strlen` validation.",
+			Optional:            true,
+			Type:                types.Int64Type,
+		},
+		"min": {
+			MarkdownDescription: "The minimum length of the value for the `strlen` validation.",
+			Optional:            true,
+			Type:                types.Int64Type,
+		},
+		"type": {
+			MarkdownDescription: "The type of the validation. One of `greater_than`, `less_than`, `strlen`, `regex` or `and`.",
+			Required:            true,
+			Type:                types.StringType,
+			Validators: []tfsdk.AttributeValidator{
+				stringInValidator{values: metadataValidationTypes},
+			},
+		},
+		"value": {
+			MarkdownDescription: "The value compared by `greater_than` and `less_than`, or the pattern of the `regex` validation.",
+			Optional:            true,
+			Type:                types.StringType,
+		},
+	}
+}
+
+func (t metadataFieldResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Metadata Field resource.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"default_value": {
+				MarkdownDescription: "The default value of the field. Integers are given as strings and dates as `YYYY-MM-DD`. Use `default_values` for `set` fields.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"default_values": {
+				MarkdownDescription: "The external IDs of the datasource values selected by default in a `set` field.",
+				Optional:            true,
+				Type:                types.ListType{ElemType: types.StringType},
+			},
+			"external_id": {
+				MarkdownDescription: "The external ID of the field.",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"label": {
+				MarkdownDescription: "The label of the field.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"mandatory": {
+				MarkdownDescription: "Whether a value must be given for the field. Mandatory fields require a default value.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.BoolType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"type": {
+				MarkdownDescription: "The type of the field. One of `string`, `integer`, `date`, `enum` or `set`.",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: metadataFieldTypes},
+				},
+			},
+		},
+
+		Blocks: map[string]tfsdk.Block{
+			"datasource": {
+				MarkdownDescription: "The values which can be selected in `enum` and `set` fields. When omitted, the values are not managed by this resource.",
+				NestingMode:         tfsdk.BlockNestingModeList,
+				MaxItems:            1,
+				Blocks: map[string]tfsdk.Block{
+					"value": {
+						MarkdownDescription: "A value of the datasource.",
+						NestingMode:         tfsdk.BlockNestingModeList,
+						MinItems:            1,
+						Attributes: map[string]tfsdk.Attribute{
+							"external_id": {
+								MarkdownDescription: "The external ID of the value. Generated when omitted.",
+								Computed:            true,
+								Optional:            true,
+								Type:                types.StringType,
+							},
+							"value": {
+								MarkdownDescription: "The value.",
+								Required:            true,
+								Type:                types.StringType,
+							},
+						},
+					},
+				},
+			},
+			"validation": {
+				MarkdownDescription: "The validation rule applied to the values of the field.",
+				NestingMode:         tfsdk.BlockNestingModeList,
+				MaxItems:            1,
+				Attributes:          metadataValidationRuleAttributes(),
+				Blocks: map[string]tfsdk.Block{
+					"rule": {
+						MarkdownDescription: "The rules combined by an `and` validation.",
+						NestingMode:         tfsdk.BlockNestingModeList,
+						Attributes:          metadataValidationRuleAttributes(),
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (t metadataFieldResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return metadataFieldResource{
+		provider: provider,
+	}, diags
+}
+
+type metadataDatasourceValueData struct {
+	ExternalID types.String `tfsdk:"external_id"`
+	Value      types.String `tfsdk:"value"`
+}
+
+type metadataDatasourceData struct {
+	Values []metadataDatasourceValueData `tfsdk:"value"`
+}
+
+type metadataValidationRuleData struct {
+	Equals types.Bool   `tfsdk:"equals"`
+	Max    types.Int64  `tfsdk:"max"`
+	Min    types.Int64  `tfsdk:"min"`
+	Type   types.String `tfsdk:"type"`
+	Value  types.String `tfsdk:"value"`
+}
+
+type metadataValidationData struct {
+	Equals types.Bool                   `tfsdk:"equals"`
+	Max    types.Int64                  `tfsdk:"max"`
+	Min    types.Int64                  `tfsdk:"min"`
+	Rules  []metadataValidationRuleData `tfsdk:"rule"`
+	Type   types.String                 `tfsdk:"type"`
+	Value  types.String                 `tfsdk:"value"`
+}
+
+func (v metadataValidationData) rule() metadataValidationRuleData {
+	return metadataValidationRuleData{
+		Equals: v.Equals,
+		Max:    v.Max,
+		Min:    v.Min,
+		Type:   v.Type,
+		Value:  v.Value,
+	}
+}
+
+type metadataFieldResourceData struct {
+	Datasource    []metadataDatasourceData `tfsdk:"datasource"`
+	DefaultValue  types.String             `tfsdk:"default_value"`
+	DefaultValues types.List               `tfsdk:"default_values"`
+	ExternalID    types.String             `tfsdk:"external_id"`
+	ID            types.String             `tfsdk:"id"`
+	Label         types.String             `tfsdk:"label"`
+	Mandatory     types.Bool               `tfsdk:"mandatory"`
+	Type          types.String             `tfsdk:"type"`
+	Validation    []metadataValidationData `tfsdk:"validation"`
+}
+
+// metadataFieldParams are the parameters of the create and update metadata
+// field calls. Unlike metadata.Field, the datasource is omitted when it is
+// not managed and the default value and validation are always sent so that
+// they can be removed.
+type metadataFieldParams struct {
+	ExternalID   string                    `json:"external_id,omitempty"`
+	Type         string                    `json:"type,omitempty"`
+	Label        string                    `json:"label"`
+	Mandatory    bool                      `json:"mandatory"`
+	DefaultValue interface{}               `json:"default_value"`
+	Validation   interface{}               `json:"validation"`
+	DataSource   *metadataDatasourceParams `json:"datasource,omitempty"`
+}
+
+// metadataDatasourceParams is the datasource of the create metadata field and
+// update datasource calls. External IDs are omitted for new values so that
+// Cloudinary generates them.
+type metadataDatasourceParams struct {
+	Values []metadataDatasourceValue `json:"values"`
+}
+
+type metadataDatasourceValue struct {
+	ExternalID string `json:"external_id,omitempty"`
+	Value      string `json:"value"`
+}
+
+type metadataFieldResource struct {
+	provider provider
+}
+
+func (r metadataFieldResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data metadataFieldResourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || data.Type.Unknown {
+		return
+	}
+
+	fieldType := data.Type.Value
+
+	if len(data.Datasource) > 0 && fieldType != "enum" && fieldType != "set" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("datasource"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("A datasource can only be set for enum and set fields, not %s fields.", fieldType),
+		)
+	}
+
+	if fieldType == "set" && !data.DefaultValue.Null {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_value"),
+			"Invalid Attribute Combination",
+			"Use default_values for set fields.",
+		)
+	}
+
+	if fieldType != "set" && !data.DefaultValues.Null {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_values"),
+			"Invalid Attribute Combination",
+			"default_values can only be set for set fields, use default_value instead.",
+		)
+	}
+
+	if fieldType == "integer" && !data.DefaultValue.Null && !data.DefaultValue.Unknown {
+		if _, err := strconv.ParseInt(data.DefaultValue.Value, 10, 64); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("default_value"),
+				"Invalid Attribute Value",
+				fmt.Sprintf("The default value %q of an integer field must be an integer.", data.DefaultValue.Value),
+			)
+		}
+	}
+
+	if data.Mandatory.Value && data.DefaultValue.Null && data.DefaultValues.Null {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("mandatory"),
+			"Missing Attribute",
+			"Mandatory fields require a default value.",
+		)
+	}
+
+	for _, v := range data.Validation {
+		p := path.Root("validation").AtListIndex(0)
+
+		validateMetadataValidationRule(fieldType, v.rule(), p, &resp.Diagnostics)
+
+		if v.Type.Value == "and" && len(v.Rules) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				p.AtName("rule"),
+				"Missing Attribute",
+				"An and validation requires at least one rule.",
+			)
+		}
+
+		if !v.Type.Unknown && v.Type.Value != "and" && len(v.Rules) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				p.AtName("rule"),
+				"Invalid Attribute Combination",
+				"Rules can only be set for and validations.",
+			)
+		}
+
+		for i, rule := range v.Rules {
+			if rule.Type.Value == "and" {
+				resp.Diagnostics.AddAttributeError(
+					p.AtName("rule").AtListIndex(i).AtName("type"),
+					"Invalid Attribute Value",
+					"And validations cannot be nested.",
+				)
+				continue
+			}
+
+			validateMetadataValidationRule(fieldType, rule, p.AtName("rule").AtListIndex(i), &resp.Diagnostics)
+		}
+	}
+}
+
+// validateMetadataValidationRule checks that the attributes required by the
+// type of the rule are set.
+func validateMetadataValidationRule(fieldType string, rule metadataValidationRuleData, p path.Path, diags *diag.Diagnostics) {
+	switch rule.Type.Value {
+	case "greater_than", "less_than":
+		if fieldType != "integer" && fieldType != "date" {
+			diags.AddAttributeError(
+				p.AtName("type"),
+				"Invalid Attribute Value",
+				fmt.Sprintf("The %s validation can only be used with integer and date fields.", rule.Type.Value),
+			)
+		}
+		if rule.Value.Null {
+			diags.AddAttributeError(
+				p.AtName("value"),
+				"Missing Attribute",
+				fmt.Sprintf("The %s validation requires a value.", rule.Type.Value),
+			)
+		}
+	case "regex":
+		if rule.Value.Null {
+			diags.AddAttributeError(
+				p.AtName("value"),
+				"Missing Attribute",
+				"The regex validation requires a value.",
+			)
+		}
+	case "strlen":
+		if fieldType != "string" {
+			diags.AddAttributeError(
+				p.AtName("type"),
+				"Invalid Attribute Value",
+				"The strlen validation can only be used with string fields.",
+			)
+		}
+		if rule.Min.Null && rule.Max.Null {
+			diags.AddAttributeError(
+				p,
+				"Missing Attribute",
+				"The strlen validation requires min or max.",
+			)
+		}
+	}
+}
+
+func (r metadataFieldResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data metadataFieldResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.ExternalID
+
+	params, diags := metadataFieldParamsFromData(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params.ExternalID = data.ExternalID.Value
+	params.Type = data.Type.Value
+
+	if len(data.Datasource) > 0 {
+		params.DataSource = &metadataDatasourceParams{
+			Values: expandMetadataDatasourceValues(data.Datasource[0].Values, nil),
+		}
+	}
+
+	var res admin.AddMetadataFieldResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodPost, "metadata_fields", params, &res)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create metadata field, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create metadata field, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r metadataFieldResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data metadataFieldResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.ExternalID
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r metadataFieldResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, state metadataFieldResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.ExternalID
+
+	params, diags := metadataFieldParamsFromData(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The datasource values can only be changed through the datasource
+	// endpoints, which is done first so that a new default value can refer
+	// to a new datasource value.
+	if len(data.Datasource) > 0 {
+		var current []metadataDatasourceValueData
+		if len(state.Datasource) > 0 {
+			current = state.Datasource[0].Values
+		}
+
+		resp.Diagnostics.Append(r.updateDatasource(ctx, data.ExternalID.Value, data.Datasource[0].Values, current)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var res admin.UpdateMetadataFieldResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodPut, api.BuildPath("metadata_fields", data.ExternalID.Value), params, &res)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update metadata field, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update metadata field, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r metadataFieldResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data metadataFieldResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := admin.DeleteMetadataFieldParams{
+		FieldExternalID: data.ExternalID.Value,
+	}
+
+	res, err := r.provider.client.Admin.DeleteMetadataField(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete metadata field, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete metadata field, got error: %s", res.Error.Message),
+		)
+		return
+	}
+}
+
+func (r metadataFieldResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, path.Root("external_id"), req, resp)
+}
+
+// updateDatasource makes the active datasource values of the field match
+// values. Values removed from the configuration are deactivated and values
+// added back are restored.
+func (r metadataFieldResource) updateDatasource(ctx context.Context, externalID string, values []metadataDatasourceValueData, current []metadataDatasourceValueData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	field, err := r.provider.client.Admin.MetadataFieldByFieldID(ctx, admin.MetadataFieldByFieldIDParams{FieldExternalID: externalID})
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read metadata field, got error: %s", err),
+		)
+		return diags
+	}
+
+	if field.Error.Message != "" {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read metadata field, got error: %s", field.Error.Message),
+		)
+		return diags
+	}
+
+	expanded := expandMetadataDatasourceValues(values, current)
+
+	keep := map[string]bool{}
+	for _, v := range expanded {
+		keep[v.ExternalID] = true
+	}
+
+	var removed, restored []string
+	for _, v := range field.DataSource.Values {
+		switch {
+		case !keep[v.ExternalID] && v.State != "inactive":
+			removed = append(removed, v.ExternalID)
+		case keep[v.ExternalID] && v.State == "inactive":
+			restored = append(restored, v.ExternalID)
+		}
+	}
+
+	if len(removed) > 0 {
+		res, err := r.provider.client.Admin.DeleteDataSourceEntries(ctx, admin.DeleteDataSourceEntriesParams{
+			FieldExternalID:    externalID,
+			EntriesExternalIDs: removed,
+		})
+		if err == nil && res.Error.Message != "" {
+			err = fmt.Errorf("%s", res.Error.Message)
+		}
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to delete metadata datasource values, got error: %s", err),
+			)
+			return diags
+		}
+	}
+
+	if len(restored) > 0 {
+		res, err := r.provider.client.Admin.RestoreDatasourceEntries(ctx, admin.RestoreDatasourceEntriesParams{
+			FieldExternalID:    externalID,
+			EntriesExternalIDs: restored,
+		})
+		if err == nil && res.Error.Message != "" {
+			err = fmt.Errorf("%s", res.Error.Message)
+		}
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to restore metadata datasource values, got error: %s", err),
+			)
+			return diags
+		}
+	}
+
+	var res admin.UpdateMetadataFieldDataSourceResult
+
+	err = callAdminAPI(ctx, r.provider.client, http.MethodPut, api.BuildPath("metadata_fields", externalID, "datasource"), metadataDatasourceParams{Values: expanded}, &res)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update metadata datasource, got error: %s", err),
+		)
+		return diags
+	}
+
+	if res.Error.Message != "" {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update metadata datasource, got error: %s", res.Error.Message),
+		)
+	}
+
+	return diags
+}
+
+// read refreshes data with the metadata field stored by Cloudinary. The
+// datasource is only refreshed when it is managed by the resource.
+func (r metadataFieldResource) read(ctx context.Context, data *metadataFieldResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	params := admin.MetadataFieldByFieldIDParams{
+		FieldExternalID: data.ExternalID.Value,
+	}
+
+	res, err := r.provider.client.Admin.MetadataFieldByFieldID(ctx, params)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read metadata field, got error: %s", err),
+		)
+		return diags
+	}
+
+	if res.Error.Message != "" {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read metadata field, got error: %s", res.Error.Message),
+		)
+		return diags
+	}
+
+	data.Label = types.String{Value: res.Label}
+	data.Mandatory = types.Bool{Value: res.Mandatory}
+	data.Type = types.String{Value: string(res.Type)}
+
+	data.DefaultValue = types.String{Null: true}
+	data.DefaultValues = types.List{ElemType: types.StringType, Null: true}
+
+	switch v := res.DefaultValue.(type) {
+	case nil:
+	case []interface{}:
+		data.DefaultValues = types.List{ElemType: types.StringType}
+		for _, e := range v {
+			data.DefaultValues.Elems = append(data.DefaultValues.Elems, types.String{Value: flattenValue(e)})
+		}
+	default:
+		data.DefaultValue = types.String{Value: flattenValue(v)}
+	}
+
+	var current *metadataValidationData
+	if len(data.Validation) > 0 {
+		current = &data.Validation[0]
+	}
+
+	data.Validation = []metadataValidationData{}
+	if v, ok := res.Validation.(map[string]interface{}); ok {
+		data.Validation = append(data.Validation, flattenMetadataValidation(v, current))
+	}
+
+	if len(data.Datasource) > 0 {
+		data.Datasource = []metadataDatasourceData{
+			{Values: flattenMetadataDatasourceValues(res.DataSource.Values, data.Datasource[0].Values)},
+		}
+	} else {
+		data.Datasource = []metadataDatasourceData{}
+	}
+
+	return diags
+}
+
+// metadataFieldParamsFromData builds the parameters shared by the create and
+// update metadata field calls.
+func metadataFieldParamsFromData(ctx context.Context, data metadataFieldResourceData) (metadataFieldParams, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	params := metadataFieldParams{
+		Label:     data.Label.Value,
+		Mandatory: data.Mandatory.Value,
+	}
+
+	switch {
+	case !data.DefaultValues.Null && !data.DefaultValues.Unknown:
+		var values []string
+		diags.Append(data.DefaultValues.ElementsAs(ctx, &values, false)...)
+		params.DefaultValue = values
+	case !data.DefaultValue.Null && !data.DefaultValue.Unknown:
+		params.DefaultValue = metadataValue(data.Type.Value, data.DefaultValue.Value)
+	}
+
+	if len(data.Validation) > 0 {
+		v := data.Validation[0]
+
+		if v.Type.Value == "and" {
+			rules := make([]interface{}, 0, len(v.Rules))
+			for _, rule := range v.Rules {
+				rules = append(rules, expandMetadataValidationRule(data.Type.Value, rule))
+			}
+			params.Validation = map[string]interface{}{
+				"type":  "and",
+				"rules": rules,
+			}
+		} else {
+			params.Validation = expandMetadataValidationRule(data.Type.Value, v.rule())
+		}
+	}
+
+	return params, diags
+}
+
+// metadataValue converts a value given as a string to the JSON type of the
+// field.
+func metadataValue(fieldType string, v string) interface{} {
+	if fieldType == "integer" {
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return i
+		}
+	}
+
+	return v
+}
+
+func expandMetadataValidationRule(fieldType string, rule metadataValidationRuleData) map[string]interface{} {
+	m := map[string]interface{}{
+		"type": rule.Type.Value,
+	}
+
+	switch rule.Type.Value {
+	case "greater_than", "less_than":
+		m["value"] = metadataValue(fieldType, rule.Value.Value)
+		m["equals"] = rule.Equals.Value
+	case "regex":
+		m["value"] = rule.Value.Value
+	case "strlen":
+		if !rule.Min.Null && !rule.Min.Unknown {
+			m["min"] = rule.Min.Value
+		}
+		if !rule.Max.Null && !rule.Max.Unknown {
+			m["max"] = rule.Max.Value
+		}
+	}
+
+	return m
+}
+
+// flattenMetadataValidationRule converts a validation rule returned by the
+// Admin API. Optional attributes stay null while Cloudinary returns their
+// default value.
+func flattenMetadataValidationRule(m map[string]interface{}, current *metadataValidationRuleData) metadataValidationRuleData {
+	rule := metadataValidationRuleData{
+		Equals: types.Bool{Null: true},
+		Max:    types.Int64{Null: true},
+		Min:    types.Int64{Null: true},
+		Type:   types.String{Value: fmt.Sprint(m["type"])},
+		Value:  types.String{Null: true},
+	}
+
+	if v, ok := m["value"]; ok && v != nil {
+		rule.Value = types.String{Value: flattenValue(v)}
+	}
+
+	if v, ok := m["equals"].(bool); ok && (v || (current != nil && !current.Equals.Null)) {
+		rule.Equals = types.Bool{Value: v}
+	}
+
+	if v, ok := m["min"].(float64); ok {
+		rule.Min = types.Int64{Value: int64(v)}
+	}
+
+	if v, ok := m["max"].(float64); ok {
+		rule.Max = types.Int64{Value: int64(v)}
+	}
+
+	return rule
+}
+
+func flattenMetadataValidation(m map[string]interface{}, current *metadataValidationData) metadataValidationData {
+	var currentRule *metadataValidationRuleData
+	if current != nil {
+		r := current.rule()
+		currentRule = &r
+	}
+
+	rule := flattenMetadataValidationRule(m, currentRule)
+
+	v := metadataValidationData{
+		Equals: rule.Equals,
+		Max:    rule.Max,
+		Min:    rule.Min,
+		Rules:  []metadataValidationRuleData{},
+		Type:   rule.Type,
+		Value:  rule.Value,
+	}
+
+	rules, _ := m["rules"].([]interface{})
+	for i, e := range rules {
+		r, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var c *metadataValidationRuleData
+		if current != nil && i < len(current.Rules) {
+			c = &current.Rules[i]
+		}
+
+		v.Rules = append(v.Rules, flattenMetadataValidationRule(r, c))
+	}
+
+	return v
+}
+
+// expandMetadataDatasourceValues converts the configured datasource values.
+// Values without a known external ID reuse the external ID of the current
+// value with the same text, so that they are updated rather than created.
+func expandMetadataDatasourceValues(values []metadataDatasourceValueData, current []metadataDatasourceValueData) []metadataDatasourceValue {
+	ids := map[string]string{}
+	for _, v := range current {
+		ids[v.Value.Value] = v.ExternalID.Value
+	}
+
+	expanded := make([]metadataDatasourceValue, 0, len(values))
+	for _, v := range values {
+		externalID := v.ExternalID.Value
+		if v.ExternalID.Null || v.ExternalID.Unknown {
+			externalID = ids[v.Value.Value]
+		}
+
+		expanded = append(expanded, metadataDatasourceValue{
+			ExternalID: externalID,
+			Value:      v.Value.Value,
+		})
+	}
+
+	return expanded
+}
+
+// flattenMetadataDatasourceValues converts the active datasource values
+// returned by the Admin API, in the order of the current values. Values are
+// matched by external ID or, when it is not known yet, by text.
+func flattenMetadataDatasourceValues(remote []metadata.DataSourceValue, current []metadataDatasourceValueData) []metadataDatasourceValueData {
+	var active []metadata.DataSourceValue
+	for _, v := range remote {
+		if v.State != "inactive" {
+			active = append(active, v)
+		}
+	}
+
+	used := make([]bool, len(active))
+	values := []metadataDatasourceValueData{}
+
+	for _, c := range current {
+		for i, v := range active {
+			if used[i] {
+				continue
+			}
+
+			if (!c.ExternalID.Null && !c.ExternalID.Unknown && c.ExternalID.Value == v.ExternalID) ||
+				((c.ExternalID.Null || c.ExternalID.Unknown) && c.Value.Value == v.Value) {
+				used[i] = true
+				values = append(values, metadataDatasourceValueData{
+					ExternalID: types.String{Value: v.ExternalID},
+					Value:      types.String{Value: v.Value},
+				})
+				break
+			}
+		}
+	}
+
+	for i, v := range active {
+		if !used[i] {
+			values = append(values, metadataDatasourceValueData{
+				ExternalID: types.String{Value: v.ExternalID},
+				Value:      types.String{Value: v.Value},
+			})
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/api/admin/metadata"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMetadataFieldResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccMetadataFieldResourceConfig("Terraform Acceptance Test", 10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_metadata_field.test", "external_id", "terraform_acc_test"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_field.test", "type", "integer"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_field.test", "label", "Terraform Acceptance Test"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_field.test", "mandatory", "true"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_field.test", "default_value", "10"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_field.test", "validation.0.type", "and"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_field.test", "validation.0.rule.#", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "cloudinary_metadata_field.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccMetadataFieldResourceConfig("Terraform Acceptance Test (updated)", 20),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_metadata_field.test", "label", "Terraform Acceptance Test (updated)"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_field.test", "default_value", "20"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccMetadataFieldResource_datasource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccMetadataFieldResourceDatasourceConfig(`["Green"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_metadata_field.test", "type", "enum"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_field.test", "datasource.0.value.#", "2"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_field.test", "datasource.0.value.0.external_id", "red"),
+					resource.TestCheckResourceAttrSet("cloudinary_metadata_field.test", "datasource.0.value.1.external_id"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccMetadataFieldResourceDatasourceConfig(`["Green", "Blue"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_metadata_field.test", "datasource.0.value.#", "3"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_field.test", "datasource.0.value.2.value", "Blue"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccMetadataFieldResourceConfig(label string, defaultValue int) string {
+	return fmt.Sprintf(`
+resource "cloudinary_metadata_field" "test" {
+  external_id   = "terraform_acc_test"
+  type          = "integer"
+  label         = %[1]q
+  mandatory     = true
+  default_value = "%[2]d"
+
+  validation {
+    type = "and"
+
+    rule {
+      type   = "greater_than"
+      value  = "0"
+      equals = true
+    }
+
+    rule {
+      type  = "less_than"
+      value = "100"
+    }
+  }
+}
+`, label, defaultValue)
+}
+
+func testAccMetadataFieldResourceDatasourceConfig(values string) string {
+	return fmt.Sprintf(`
+resource "cloudinary_metadata_field" "test" {
+  external_id = "terraform_acc_test_enum"
+  type        = "enum"
+  label       = "Terraform Acceptance Test"
+
+  datasource {
+    value {
+      external_id = "red"
+      value       = "Red"
+    }
+
+    dynamic "value" {
+      for_each = %[1]s
+
+      content {
+        value = value.value
+      }
+    }
+  }
+}
+`, values)
+}
+
+func TestFlattenMetadataDatasourceValues(t *testing.T) {
+	remote := []metadata.DataSourceValue{
+		{ExternalID: "a", Value: "A", State: "active"},
+		{ExternalID: "b", Value: "B", State: "inactive"},
+		{ExternalID: "c", Value: "C", State: "active"},
+		{ExternalID: "d", Value: "D", State: "active"},
+	}
+
+	current := []metadataDatasourceValueData{
+		{ExternalID: types.String{Unknown: true}, Value: types.String{Value: "C"}},
+		{ExternalID: types.String{Value: "a"}, Value: types.String{Value: "A"}},
+	}
+
+	got := flattenMetadataDatasourceValues(remote, current)
+	want := []metadataDatasourceValueData{
+		{ExternalID: types.String{Value: "c"}, Value: types.String{Value: "C"}},
+		{ExternalID: types.String{Value: "a"}, Value: types.String{Value: "A"}},
+		{ExternalID: types.String{Value: "d"}, Value: types.String{Value: "D"}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenMetadataDatasourceValues() = %v, want %v", got, want)
+	}
+}
+
+func TestMetadataValidation(t *testing.T) {
+	v := metadataValidationData{
+		Equals: types.Bool{Null: true},
+		Max:    types.Int64{Null: true},
+		Min:    types.Int64{Null: true},
+		Type:   types.String{Value: "and"},
+		Value:  types.String{Null: true},
+		Rules: []metadataValidationRuleData{
+			{
+				Equals: types.Bool{Value: true},
+				Max:    types.Int64{Null: true},
+				Min:    types.Int64{Null: true},
+				Type:   types.String{Value: "greater_than"},
+				Value:  types.String{Value: "5"},
+			},
+			{
+				Equals: types.Bool{Null: true},
+				Max:    types.Int64{Value: 10},
+				Min:    types.Int64{Null: true},
+				Type:   types.String{Value: "strlen"},
+				Value:  types.String{Null: true},
+			},
+		},
+	}
+
+	rules := make([]interface{}, 0, len(v.Rules))
+	for _, rule := range v.Rules {
+		rules = append(rules, expandMetadataValidationRule("integer", rule))
+	}
+
+	if got := rules[0].(map[string]interface{})["value"]; got != int64(5) {
+		t.Errorf("expanded value = %#v, want int64(5)", got)
+	}
+
+	// Simulate the JSON round trip done by the Admin API.
+	remote := map[string]interface{}{
+		"type": "and",
+		"rules": []interface{}{
+			map[string]interface{}{"type": "greater_than", "value": float64(5), "equals": true},
+			map[string]interface{}{"type": "strlen", "max": float64(10)},
+		},
+	}
+
+	if got := flattenMetadataValidation(remote, &v); !reflect.DeepEqual(got, v) {
+		t.Errorf("flattenMetadataValidation() = %v, want %v", got, v)
+	}
+}
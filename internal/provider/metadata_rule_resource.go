@@ -0,0 +1,654 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type metadataRuleResourceType struct{}
+
+func (t metadataRuleResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Metadata Rule resource. Conditional metadata rules change a metadata field depending on the values of other fields.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"condition_operator": {
+				MarkdownDescription: "How multiple conditions are combined. Either `and` (default) or `or`.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"and", "or"}},
+				},
+			},
+			"id": {
+				MarkdownDescription: "The external ID of the rule.",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"metadata_field_id": {
+				MarkdownDescription: "The external ID of the metadata field changed by the rule.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"name": {
+				MarkdownDescription: "The name of the rule.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"position": {
+				MarkdownDescription: "The position of the rule among the rules of the metadata field.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.Int64Type,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"state": {
+				MarkdownDescription: "The state of the rule. Either `active` or `inactive`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"active", "inactive"}},
+				},
+			},
+		},
+
+		Blocks: map[string]tfsdk.Block{
+			"condition": {
+				MarkdownDescription: "A condition on the value of another metadata field. Exactly one of `equals`, `includes` or `populated` must be set.",
+				NestingMode:         tfsdk.BlockNestingModeList,
+				MinItems:            1,
+				Attributes: map[string]tfsdk.Attribute{
+					"equals": {
+						MarkdownDescription: "The value the field must be equal to.",
+						Optional:            true,
+						Type:                types.StringType,
+					},
+					"includes": {
+						MarkdownDescription: "The external IDs of the datasource values the field must include.",
+						Optional:            true,
+						Type:                types.ListType{ElemType: types.StringType},
+					},
+					"metadata_field_id": {
+						MarkdownDescription: "The external ID of the metadata field the condition applies to.",
+						Required:            true,
+						Type:                types.StringType,
+					},
+					"populated": {
+						MarkdownDescription: "Whether the field must have a value.",
+						Optional:            true,
+						Type:                types.BoolType,
+					},
+				},
+			},
+			"result": {
+				MarkdownDescription: "The changes applied to the metadata field when the conditions are met.",
+				NestingMode:         tfsdk.BlockNestingModeList,
+				MinItems:            1,
+				MaxItems:            1,
+				Attributes: map[string]tfsdk.Attribute{
+					"activate_all_values": {
+						MarkdownDescription: "Whether all the datasource values of the field can be selected. Conflicts with `activate_values`.",
+						Optional:            true,
+						Type:                types.BoolType,
+					},
+					"activate_values": {
+						MarkdownDescription: "The external IDs of the datasource values which can be selected.",
+						Optional:            true,
+						Type:                types.ListType{ElemType: types.StringType},
+					},
+					"apply_value": {
+						MarkdownDescription: "The value applied to the field. Use `apply_values` for `set` fields.",
+						Optional:            true,
+						Type:                types.StringType,
+					},
+					"apply_value_mode": {
+						MarkdownDescription: "How the value is applied. Either `default` (only when the field is empty) or `append`.",
+						Optional:            true,
+						Type:                types.StringType,
+						Validators: []tfsdk.AttributeValidator{
+							stringInValidator{values: []string{"append", "default"}},
+						},
+					},
+					"apply_values": {
+						MarkdownDescription: "The external IDs of the datasource values applied to a `set` field.",
+						Optional:            true,
+						Type:                types.ListType{ElemType: types.StringType},
+					},
+					"enable": {
+						MarkdownDescription: "Whether the field is displayed.",
+						Optional:            true,
+						Type:                types.BoolType,
+					},
+					"set_mandatory": {
+						MarkdownDescription: "Whether the field becomes mandatory.",
+						Optional:            true,
+						Type:                types.BoolType,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (t metadataRuleResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return metadataRuleResource{
+		provider: provider,
+	}, diags
+}
+
+type metadataRuleConditionData struct {
+	Equals          types.String `tfsdk:"equals"`
+	Includes        types.List   `tfsdk:"includes"`
+	MetadataFieldID types.String `tfsdk:"metadata_field_id"`
+	Populated       types.Bool   `tfsdk:"populated"`
+}
+
+type metadataRuleResultData struct {
+	ActivateAllValues types.Bool   `tfsdk:"activate_all_values"`
+	ActivateValues    types.List   `tfsdk:"activate_values"`
+	ApplyValue        types.String `tfsdk:"apply_value"`
+	ApplyValueMode    types.String `tfsdk:"apply_value_mode"`
+	ApplyValues       types.List   `tfsdk:"apply_values"`
+	Enable            types.Bool   `tfsdk:"enable"`
+	SetMandatory      types.Bool   `tfsdk:"set_mandatory"`
+}
+
+type metadataRuleResourceData struct {
+	Condition         []metadataRuleConditionData `tfsdk:"condition"`
+	ConditionOperator types.String                `tfsdk:"condition_operator"`
+	ID                types.String                `tfsdk:"id"`
+	MetadataFieldID   types.String                `tfsdk:"metadata_field_id"`
+	Name              types.String                `tfsdk:"name"`
+	Position          types.Int64                 `tfsdk:"position"`
+	Result            []metadataRuleResultData    `tfsdk:"result"`
+	State             types.String                `tfsdk:"state"`
+}
+
+// metadataRule is a conditional metadata rule as returned by the Admin API.
+type metadataRule struct {
+	ExternalID      string                 `json:"external_id"`
+	MetadataFieldID string                 `json:"metadata_field_id"`
+	Name            string                 `json:"name"`
+	Condition       map[string]interface{} `json:"condition"`
+	Result          map[string]interface{} `json:"result"`
+	State           string                 `json:"state"`
+	Position        int64                  `json:"position"`
+}
+
+type metadataRuleResult struct {
+	metadataRule
+	Error api.ErrorResp `json:"error,omitempty"`
+}
+
+type listMetadataRulesResult struct {
+	MetadataRules []metadataRule `json:"metadata_rules"`
+	Error         api.ErrorResp  `json:"error,omitempty"`
+}
+
+type deleteMetadataRuleResult struct {
+	Success bool          `json:"success"`
+	Error   api.ErrorResp `json:"error,omitempty"`
+}
+
+// metadataRuleParams are the parameters of the create and update metadata
+// rule calls.
+type metadataRuleParams struct {
+	MetadataFieldID string                 `json:"metadata_field_id"`
+	Name            string                 `json:"name"`
+	Condition       map[string]interface{} `json:"condition"`
+	Result          map[string]interface{} `json:"result"`
+	State           string                 `json:"state,omitempty"`
+	Position        *int64                 `json:"position,omitempty"`
+}
+
+type metadataRuleResource struct {
+	provider provider
+}
+
+func (r metadataRuleResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data metadataRuleResourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, c := range data.Condition {
+		n := 0
+		for _, set := range []bool{!c.Equals.Null, !c.Includes.Null, !c.Populated.Null} {
+			if set {
+				n++
+			}
+		}
+
+		if n != 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("condition").AtListIndex(i),
+				"Invalid Attribute Combination",
+				"Exactly one of equals, includes or populated must be set.",
+			)
+		}
+	}
+
+	for i, r := range data.Result {
+		p := path.Root("result").AtListIndex(i)
+
+		if r.ActivateAllValues.Value && !r.ActivateValues.Null {
+			resp.Diagnostics.AddAttributeError(
+				p.AtName("activate_values"),
+				"Conflicting Attributes",
+				"Only one of activate_all_values and activate_values can be set.",
+			)
+		}
+
+		if !r.ApplyValue.Null && !r.ApplyValues.Null {
+			resp.Diagnostics.AddAttributeError(
+				p.AtName("apply_value"),
+				"Conflicting Attributes",
+				"Only one of apply_value and apply_values can be set.",
+			)
+		}
+
+		if !r.ApplyValueMode.Null && r.ApplyValue.Null && r.ApplyValues.Null {
+			resp.Diagnostics.AddAttributeError(
+				p.AtName("apply_value_mode"),
+				"Missing Attribute",
+				"apply_value_mode requires apply_value or apply_values.",
+			)
+		}
+	}
+}
+
+func (r metadataRuleResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data metadataRuleResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params, diags := metadataRuleParamsFromData(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var res metadataRuleResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodPost, "metadata_rules", params, &res)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create metadata rule, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create metadata rule, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	data.ID = types.String{Value: res.ExternalID}
+	data.refresh(res.metadataRule)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r metadataRuleResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data metadataRuleResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// There is no endpoint returning a single rule.
+	var res listMetadataRulesResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodGet, "metadata_rules", nil, &res)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read metadata rule, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read metadata rule, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	for _, rule := range res.MetadataRules {
+		if rule.ExternalID == data.ID.Value {
+			data.refresh(rule)
+
+			diags = resp.State.Set(ctx, &data)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
+	tflog.Warn(ctx, "metadata rule not found, removing it from the state", map[string]interface{}{
+		"id": data.ID.Value,
+	})
+	resp.State.RemoveResource(ctx)
+}
+
+func (r metadataRuleResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data metadataRuleResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params, diags := metadataRuleParamsFromData(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var res metadataRuleResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodPut, api.BuildPath("metadata_rules", data.ID.Value), params, &res)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update metadata rule, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update metadata rule, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	data.refresh(res.metadataRule)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r metadataRuleResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data metadataRuleResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var res deleteMetadataRuleResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodDelete, api.BuildPath("metadata_rules", data.ID.Value), nil, &res)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete metadata rule, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete metadata rule, got error: %s", res.Error.Message),
+		)
+		return
+	}
+}
+
+func (r metadataRuleResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// refresh updates data with the rule returned by Cloudinary.
+func (data *metadataRuleResourceData) refresh(rule metadataRule) {
+	data.MetadataFieldID = types.String{Value: rule.MetadataFieldID}
+	data.Name = types.String{Value: rule.Name}
+	data.Position = types.Int64{Value: rule.Position}
+	data.State = types.String{Value: rule.State}
+
+	var conditions []map[string]interface{}
+	operator := types.String{Null: true}
+
+	switch {
+	case rule.Condition["and"] != nil || rule.Condition["or"] != nil:
+		op := "and"
+		if rule.Condition["or"] != nil {
+			op = "or"
+		}
+
+		// Keep the default operator null when it is not configured.
+		if op == "or" || !data.ConditionOperator.Null {
+			operator = types.String{Value: op}
+		}
+
+		list, _ := rule.Condition[op].([]interface{})
+		for _, c := range list {
+			if m, ok := c.(map[string]interface{}); ok {
+				conditions = append(conditions, m)
+			}
+		}
+	default:
+		operator = data.ConditionOperator
+		conditions = append(conditions, rule.Condition)
+	}
+
+	data.ConditionOperator = operator
+	data.Condition = make([]metadataRuleConditionData, 0, len(conditions))
+
+	for _, m := range conditions {
+		c := metadataRuleConditionData{
+			Equals:          types.String{Null: true},
+			Includes:        types.List{ElemType: types.StringType, Null: true},
+			MetadataFieldID: types.String{Value: fmt.Sprint(m["metadata_field_id"])},
+			Populated:       types.Bool{Null: true},
+		}
+
+		if v, ok := m["equals"]; ok && v != nil {
+			c.Equals = types.String{Value: flattenValue(v)}
+		}
+
+		if v, ok := m["includes"].([]interface{}); ok {
+			c.Includes = metadataRuleList(v)
+		}
+
+		if v, ok := m["populated"].(bool); ok {
+			c.Populated = types.Bool{Value: v}
+		}
+
+		data.Condition = append(data.Condition, c)
+	}
+
+	var current metadataRuleResultData
+	if len(data.Result) > 0 {
+		current = data.Result[0]
+	}
+
+	result := metadataRuleResultData{
+		ActivateAllValues: metadataRuleBool(rule.Result["activate_values"] == "all", current.ActivateAllValues),
+		ActivateValues:    types.List{ElemType: types.StringType, Null: true},
+		ApplyValue:        types.String{Null: true},
+		ApplyValueMode:    types.String{Null: true},
+		ApplyValues:       types.List{ElemType: types.StringType, Null: true},
+		Enable:            metadataRuleBool(rule.Result["enable"], current.Enable),
+		SetMandatory:      metadataRuleBool(rule.Result["set_mandatory"], current.SetMandatory),
+	}
+
+	if v, ok := rule.Result["activate_values"].(map[string]interface{}); ok {
+		ids, _ := v["external_ids"].([]interface{})
+		result.ActivateValues = metadataRuleList(ids)
+	}
+
+	if v, ok := rule.Result["apply_value"].(map[string]interface{}); ok {
+		switch value := v["value"].(type) {
+		case nil:
+		case []interface{}:
+			result.ApplyValues = metadataRuleList(value)
+		default:
+			result.ApplyValue = types.String{Value: flattenValue(value)}
+		}
+
+		if mode, ok := v["mode"].(string); ok && (mode != "default" || !current.ApplyValueMode.Null) {
+			result.ApplyValueMode = types.String{Value: mode}
+		}
+	}
+
+	data.Result = []metadataRuleResultData{result}
+}
+
+// metadataRuleBool returns a boolean of the rule result, keeping it null
+// while it is not configured and false.
+func metadataRuleBool(v interface{}, current types.Bool) types.Bool {
+	b, _ := v.(bool)
+	if !b && current.Null {
+		return types.Bool{Null: true}
+	}
+
+	return types.Bool{Value: b}
+}
+
+func metadataRuleList(v []interface{}) types.List {
+	list := types.List{ElemType: types.StringType, Elems: []attr.Value{}}
+	for _, e := range v {
+		list.Elems = append(list.Elems, types.String{Value: fmt.Sprint(e)})
+	}
+
+	return list
+}
+
+// metadataRuleParamsFromData builds the parameters of the create and update
+// metadata rule calls.
+func metadataRuleParamsFromData(ctx context.Context, data metadataRuleResourceData) (metadataRuleParams, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	params := metadataRuleParams{
+		MetadataFieldID: data.MetadataFieldID.Value,
+		Name:            data.Name.Value,
+		Result:          map[string]interface{}{},
+	}
+
+	if !data.State.Null && !data.State.Unknown {
+		params.State = data.State.Value
+	}
+
+	if !data.Position.Null && !data.Position.Unknown {
+		params.Position = &data.Position.Value
+	}
+
+	conditions := make([]interface{}, 0, len(data.Condition))
+	for _, c := range data.Condition {
+		m := map[string]interface{}{
+			"metadata_field_id": c.MetadataFieldID.Value,
+		}
+
+		switch {
+		case !c.Equals.Null:
+			m["equals"] = c.Equals.Value
+		case !c.Includes.Null:
+			var includes []string
+			diags.Append(c.Includes.ElementsAs(ctx, &includes, false)...)
+			m["includes"] = includes
+		case !c.Populated.Null:
+			m["populated"] = c.Populated.Value
+		}
+
+		conditions = append(conditions, m)
+	}
+
+	if len(conditions) == 1 {
+		params.Condition = conditions[0].(map[string]interface{})
+	} else {
+		operator := "and"
+		if !data.ConditionOperator.Null {
+			operator = data.ConditionOperator.Value
+		}
+		params.Condition = map[string]interface{}{operator: conditions}
+	}
+
+	if len(data.Result) > 0 {
+		r := data.Result[0]
+
+		if !r.Enable.Null {
+			params.Result["enable"] = r.Enable.Value
+		}
+
+		if !r.SetMandatory.Null {
+			params.Result["set_mandatory"] = r.SetMandatory.Value
+		}
+
+		if r.ActivateAllValues.Value {
+			params.Result["activate_values"] = "all"
+		} else if !r.ActivateValues.Null {
+			var ids []string
+			diags.Append(r.ActivateValues.ElementsAs(ctx, &ids, false)...)
+			params.Result["activate_values"] = map[string]interface{}{"external_ids": ids}
+		}
+
+		applyValue := map[string]interface{}{}
+		if !r.ApplyValue.Null {
+			applyValue["value"] = r.ApplyValue.Value
+		} else if !r.ApplyValues.Null {
+			var values []string
+			diags.Append(r.ApplyValues.ElementsAs(ctx, &values, false)...)
+			applyValue["value"] = values
+		}
+
+		if len(applyValue) > 0 {
+			if !r.ApplyValueMode.Null {
+				applyValue["mode"] = r.ApplyValueMode.Value
+			}
+			params.Result["apply_value"] = applyValue
+		}
+	}
+
+	return params, diags
+}
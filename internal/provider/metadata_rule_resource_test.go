@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMetadataRuleResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccMetadataRuleResourceConfig("active"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("cloudinary_metadata_rule.test", "id"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_rule.test", "metadata_field_id", "terraform_acc_test_size"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_rule.test", "state", "active"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_rule.test", "condition.0.equals", "shoes"),
+					resource.TestCheckResourceAttr("cloudinary_metadata_rule.test", "result.0.enable", "true"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "cloudinary_metadata_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccMetadataRuleResourceConfig("inactive"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_metadata_rule.test", "state", "inactive"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccMetadataRuleResourceConfig(state string) string {
+	return fmt.Sprintf(`
+resource "cloudinary_metadata_field" "category" {
+  external_id = "terraform_acc_test_category"
+  type        = "enum"
+  label       = "Terraform Acceptance Test Category"
+
+  datasource {
+    value {
+      external_id = "shoes"
+      value       = "Shoes"
+    }
+  }
+}
+
+resource "cloudinary_metadata_field" "size" {
+  external_id = "terraform_acc_test_size"
+  type        = "string"
+  label       = "Terraform Acceptance Test Size"
+}
+
+resource "cloudinary_metadata_rule" "test" {
+  metadata_field_id = cloudinary_metadata_field.size.external_id
+  name              = "Show size for shoes"
+  state             = %[1]q
+
+  condition {
+    metadata_field_id = cloudinary_metadata_field.category.external_id
+    equals            = "shoes"
+  }
+
+  result {
+    enable = true
+  }
+}
+`, state)
+}
+
+func TestMetadataRuleParams(t *testing.T) {
+	ctx := context.Background()
+
+	data := metadataRuleResourceData{
+		Condition: []metadataRuleConditionData{
+			{
+				Equals:          types.String{Value: "shoes"},
+				Includes:        types.List{ElemType: types.StringType, Null: true},
+				MetadataFieldID: types.String{Value: "category"},
+				Populated:       types.Bool{Null: true},
+			},
+			{
+				Equals:          types.String{Null: true},
+				Includes:        types.List{ElemType: types.StringType, Null: true},
+				MetadataFieldID: types.String{Value: "brand"},
+				Populated:       types.Bool{Value: true},
+			},
+		},
+		ConditionOperator: types.String{Value: "or"},
+		ID:                types.String{Value: "rule"},
+		MetadataFieldID:   types.String{Value: "size"},
+		Name:              types.String{Value: "Show size"},
+		Position:          types.Int64{Unknown: true},
+		Result: []metadataRuleResultData{
+			{
+				ActivateAllValues: types.Bool{Null: true},
+				ActivateValues:    types.List{ElemType: types.StringType, Elems: []attr.Value{types.String{Value: "s"}, types.String{Value: "m"}}},
+				ApplyValue:        types.String{Value: "m"},
+				ApplyValueMode:    types.String{Null: true},
+				ApplyValues:       types.List{ElemType: types.StringType, Null: true},
+				Enable:            types.Bool{Value: true},
+				SetMandatory:      types.Bool{Null: true},
+			},
+		},
+		State: types.String{Unknown: true},
+	}
+
+	params, diags := metadataRuleParamsFromData(ctx, data)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	b, err := json.Marshal(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"metadata_field_id":"size","name":"Show size",` +
+		`"condition":{"or":[{"equals":"shoes","metadata_field_id":"category"},{"metadata_field_id":"brand","populated":true}]},` +
+		`"result":{"activate_values":{"external_ids":["s","m"]},"apply_value":{"value":"m"},"enable":true}}`
+	if string(b) != want {
+		t.Errorf("params = %s, want %s", b, want)
+	}
+
+	// The rule returned by Cloudinary, which fills the defaults in.
+	var rule metadataRule
+	if err := json.Unmarshal(b, &rule); err != nil {
+		t.Fatal(err)
+	}
+	rule.ExternalID = "rule"
+	rule.Position = 1
+	rule.State = "active"
+	rule.Result["apply_value"].(map[string]interface{})["mode"] = "default"
+	rule.Result["set_mandatory"] = false
+
+	got := data
+	got.refresh(rule)
+
+	want2 := data
+	want2.Position = types.Int64{Value: 1}
+	want2.State = types.String{Value: "active"}
+
+	if !reflect.DeepEqual(got, want2) {
+		t.Errorf("refresh() = %+v, want %+v", got, want2)
+	}
+}
+
+func TestMetadataRuleRefresh_activateAllValuesFalse(t *testing.T) {
+	result := metadataRuleResultData{
+		ActivateAllValues: types.Bool{Value: false},
+		ActivateValues:    types.List{ElemType: types.StringType, Null: true},
+		ApplyValue:        types.String{Null: true},
+		ApplyValueMode:    types.String{Null: true},
+		ApplyValues:       types.List{ElemType: types.StringType, Null: true},
+		Enable:            types.Bool{Value: true},
+		SetMandatory:      types.Bool{Null: true},
+	}
+
+	data := metadataRuleResourceData{
+		Condition: []metadataRuleConditionData{
+			{
+				Equals:          types.String{Null: true},
+				Includes:        types.List{ElemType: types.StringType, Null: true},
+				MetadataFieldID: types.String{Value: "brand"},
+				Populated:       types.Bool{Value: true},
+			},
+		},
+		ConditionOperator: types.String{Null: true},
+		ID:                types.String{Value: "rule"},
+		MetadataFieldID:   types.String{Value: "size"},
+		Name:              types.String{Value: "Show size"},
+		Position:          types.Int64{Value: 1},
+		Result:            []metadataRuleResultData{result},
+		State:             types.String{Value: "active"},
+	}
+
+	rule := metadataRule{
+		ExternalID:      "rule",
+		MetadataFieldID: "size",
+		Name:            "Show size",
+		Condition:       map[string]interface{}{"metadata_field_id": "brand", "populated": true},
+		Result:          map[string]interface{}{"enable": true},
+		State:           "active",
+		Position:        1,
+	}
+
+	got := data
+	got.refresh(rule)
+
+	if !reflect.DeepEqual(got, data) {
+		t.Errorf("refresh() = %+v, want %+v", got, data)
+	}
+
+	// Activating all values outside of Terraform shows up as a difference.
+	rule.Result["activate_values"] = "all"
+	got.refresh(rule)
+
+	if v := got.Result[0].ActivateAllValues; v.Null || !v.Value {
+		t.Errorf("refresh() activate_all_values = %s, want true", v)
+	}
+}
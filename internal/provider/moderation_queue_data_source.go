@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/cloudinary/cloudinary-go/api/admin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type moderationQueueDataSourceType struct{}
+
+func (t moderationQueueDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Moderation queue data source. Lists the assets with a moderation status for a moderation kind.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"assets": {
+				MarkdownDescription: "The assets in the moderation queue.",
+				Attributes: tfsdk.ListNestedAttributes(
+					map[string]tfsdk.Attribute{
+						"asset_id": {
+							MarkdownDescription: "The immutable ID of the asset.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"created_at": {
+							MarkdownDescription: "The RFC 3339 timestamp when the asset was uploaded.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"public_id": {
+							MarkdownDescription: "The public ID of the asset.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"secure_url": {
+							MarkdownDescription: "The HTTPS delivery URL of the asset.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+						"type": {
+							MarkdownDescription: "The delivery type of the asset.",
+							Computed:            true,
+							Type:                types.StringType,
+						},
+					},
+				),
+				Computed: true,
+			},
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"kind": {
+				MarkdownDescription: "The moderation kind, e.g. `manual` (default), `webpurify` or `aws_rek`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"resource_type": {
+				MarkdownDescription: "The resource type of the assets. One of `image` (default), `video` or `raw`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"image", "raw", "video"}},
+				},
+			},
+			"status": {
+				MarkdownDescription: "The moderation status of the assets. One of `pending`, `approved` or `rejected`.",
+				Required:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: moderationStatuses},
+				},
+			},
+		},
+	}, nil
+}
+
+func (t moderationQueueDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return moderationQueueDataSource{
+		provider: provider,
+	}, diags
+}
+
+type moderationQueueAssetData struct {
+	AssetID   types.String `tfsdk:"asset_id"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	PublicID  types.String `tfsdk:"public_id"`
+	SecureURL types.String `tfsdk:"secure_url"`
+	Type      types.String `tfsdk:"type"`
+}
+
+type moderationQueueDataSourceData struct {
+	Assets       []moderationQueueAssetData `tfsdk:"assets"`
+	ID           types.String               `tfsdk:"id"`
+	Kind         types.String               `tfsdk:"kind"`
+	ResourceType types.String               `tfsdk:"resource_type"`
+	Status       types.String               `tfsdk:"status"`
+}
+
+type moderationQueueDataSource struct {
+	provider provider
+}
+
+func (d moderationQueueDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data moderationQueueDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Kind.Null {
+		data.Kind = types.String{Value: manualModerationKind}
+	}
+	if data.ResourceType.Null {
+		data.ResourceType = types.String{Value: "image"}
+	}
+
+	data.ID = types.String{Value: api.BuildPath(data.ResourceType.Value, data.Kind.Value, data.Status.Value)}
+	data.Assets = []moderationQueueAssetData{}
+
+	params := admin.AssetsByModerationParams{
+		AssetType:  api.AssetType(data.ResourceType.Value),
+		Kind:       data.Kind.Value,
+		Status:     data.Status.Value,
+		MaxResults: maxListedAssets,
+	}
+
+	for {
+		res, err := d.provider.client.Admin.AssetsByModeration(ctx, params)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to list moderated assets, got error: %s", err),
+			)
+			return
+		}
+
+		if res.Error.Message != "" {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to list moderated assets, got error: %s", res.Error.Message),
+			)
+			return
+		}
+
+		for _, asset := range res.Assets {
+			data.Assets = append(data.Assets, moderationQueueAssetData{
+				AssetID:   types.String{Value: asset.AssetID},
+				CreatedAt: types.String{Value: asset.CreatedAt.Format(time.RFC3339)},
+				PublicID:  types.String{Value: asset.PublicID},
+				SecureURL: types.String{Value: asset.SecureURL},
+				Type:      types.String{Value: asset.Type},
+			})
+		}
+
+		if res.NextCursor == "" {
+			break
+		}
+
+		params.NextCursor = res.NextCursor
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
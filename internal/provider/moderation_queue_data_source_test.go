@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccModerationQueueDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccModerationQueueDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudinary_moderation_queue.pending", "id", "image/manual/pending"),
+					resource.TestCheckResourceAttr("data.cloudinary_moderation_queue.pending", "kind", "manual"),
+					resource.TestCheckResourceAttrSet("data.cloudinary_moderation_queue.pending", "assets.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccModerationQueueDataSourceConfig = `
+data "cloudinary_moderation_queue" "pending" {
+  status = "pending"
+}
+`
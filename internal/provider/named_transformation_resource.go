@@ -0,0 +1,367 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudinary/cloudinary-go/api/admin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type namedTransformationResourceType struct{}
+
+func (t namedTransformationResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Named Transformation resource.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"allowed_for_strict": {
+				MarkdownDescription: "Whether the transformation can be used when strict transformations are enabled.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.BoolType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"name": {
+				MarkdownDescription: "The name of the transformation, used as `t_<name>` in delivery URLs.",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"transformation": {
+				MarkdownDescription: "The transformation string (e.g. `c_fill,g_auto,h_300,w_400`). Conflicts with `step`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					transformationPlanModifier{},
+				},
+				Validators: []tfsdk.AttributeValidator{
+					transformationValidator{},
+				},
+			},
+		},
+
+		Blocks: map[string]tfsdk.Block{
+			"step": transformationStepsBlock("The chained components of the transformation. Conflicts with `transformation`."),
+		},
+	}, nil
+}
+
+func (t namedTransformationResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return namedTransformationResource{
+		provider: provider,
+	}, diags
+}
+
+type namedTransformationResourceData struct {
+	AllowedForStrict types.Bool               `tfsdk:"allowed_for_strict"`
+	ID               types.String             `tfsdk:"id"`
+	Name             types.String             `tfsdk:"name"`
+	Steps            []transformationStepData `tfsdk:"step"`
+	Transformation   types.String             `tfsdk:"transformation"`
+}
+
+// transformation returns the configured transformation string, serializing
+// the steps when they are used instead.
+func (data namedTransformationResourceData) transformation(ctx context.Context) (string, diag.Diagnostics) {
+	if len(data.Steps) > 0 {
+		return expandTransformationSteps(ctx, data.Steps)
+	}
+
+	return data.Transformation.Value, nil
+}
+
+// updateTransformationParams are the parameters of the update transformation
+// call. Unlike admin.UpdateTransformationParams, allowed_for_strict is always
+// sent so that it can be turned off again.
+type updateTransformationParams struct {
+	Transformation   string `json:"transformation"`
+	AllowedForStrict bool   `json:"allowed_for_strict"`
+	UnsafeUpdate     string `json:"unsafe_update,omitempty"`
+}
+
+type namedTransformationResource struct {
+	provider provider
+}
+
+func (r namedTransformationResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data namedTransformationResourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Transformation.Null && len(data.Steps) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("transformation"),
+			"Conflicting Attributes",
+			"Only one of transformation and step can be set.",
+		)
+	}
+
+	if data.Transformation.Null && len(data.Steps) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("transformation"),
+			"Missing Attribute",
+			"One of transformation or step must be set.",
+		)
+	}
+}
+
+func (r namedTransformationResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data namedTransformationResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Name
+
+	transformation, diags := data.transformation(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := admin.CreateTransformationParams{
+		Name:           data.Name.Value,
+		Transformation: transformation,
+	}
+
+	res, err := r.provider.client.Admin.CreateTransformation(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create named transformation, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create named transformation, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	if data.AllowedForStrict.Value {
+		resp.Diagnostics.Append(r.update(ctx, data, "")...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r namedTransformationResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data namedTransformationResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Name
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r namedTransformationResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, state namedTransformationResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Name
+
+	// Changing the definition of an existing named transformation is only
+	// accepted through unsafe_update, which leaves already derived assets
+	// untouched until they are regenerated.
+	transformation, diags := data.transformation(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var unsafeUpdate string
+	if !transformationEqual(transformation, state.Transformation.Value) {
+		unsafeUpdate = transformation
+	}
+
+	resp.Diagnostics.Append(r.update(ctx, data, unsafeUpdate)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r namedTransformationResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data namedTransformationResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := admin.DeleteTransformationParams{
+		Transformation: data.Name.Value,
+	}
+
+	res, err := r.provider.client.Admin.DeleteTransformation(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete named transformation, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete named transformation, got error: %s", res.Error.Message),
+		)
+		return
+	}
+}
+
+func (r namedTransformationResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (r namedTransformationResource) update(ctx context.Context, data namedTransformationResourceData, unsafeUpdate string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	params := updateTransformationParams{
+		Transformation:   data.Name.Value,
+		AllowedForStrict: data.AllowedForStrict.Value,
+		UnsafeUpdate:     unsafeUpdate,
+	}
+
+	var res admin.TransformationResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodPut, "transformations", params, &res)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update named transformation, got error: %s", err),
+		)
+		return diags
+	}
+
+	if res.Error.Message != "" {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update named transformation, got error: %s", res.Error.Message),
+		)
+	}
+
+	return diags
+}
+
+// read refreshes data with the transformation stored by Cloudinary. The
+// configured form is kept as long as it is equivalent to the canonical form
+// returned by Cloudinary. Steps are only refreshed when they are used instead
+// of the transformation string.
+func (r namedTransformationResource) read(ctx context.Context, data *namedTransformationResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	params := admin.GetTransformationParams{
+		Transformation: data.Name.Value,
+	}
+
+	res, err := r.provider.client.Admin.GetTransformation(ctx, params)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read named transformation, got error: %s", err),
+		)
+		return diags
+	}
+
+	if res.Error.Message != "" {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read named transformation, got error: %s", res.Error.Message),
+		)
+		return diags
+	}
+
+	transformation := flattenTransformation(res.Info)
+
+	data.AllowedForStrict = types.Bool{Value: res.AllowedForStrict}
+
+	if len(data.Steps) > 0 {
+		data.Steps = semanticTransformationSteps(ctx, data.Steps, transformation)
+	} else {
+		data.Steps = []transformationStepData{}
+	}
+
+	data.Transformation = semanticTransformation(data.Transformation, transformation)
+
+	return diags
+}
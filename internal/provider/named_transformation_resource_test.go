@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNamedTransformationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccNamedTransformationResourceConfig("c_fill,h_150,w_150"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_named_transformation.test", "name", "terraform_acc_test"),
+					resource.TestCheckResourceAttr("cloudinary_named_transformation.test", "transformation", "c_fill,h_150,w_150"),
+					resource.TestCheckResourceAttr("cloudinary_named_transformation.test", "allowed_for_strict", "true"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "cloudinary_named_transformation.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccNamedTransformationResourceConfig("c_fill,h_300,w_300"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_named_transformation.test", "transformation", "c_fill,h_300,w_300"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccNamedTransformationResourceConfig(transformation string) string {
+	return fmt.Sprintf(`
+resource "cloudinary_named_transformation" "test" {
+  name               = "terraform_acc_test"
+  transformation     = %[1]q
+  allowed_for_strict = true
+}
+`, transformation)
+}
+
+func TestAccNamedTransformationResource_step(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccNamedTransformationResourceStepConfig("400"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_named_transformation.test", "step.#", "2"),
+					resource.TestCheckResourceAttr("cloudinary_named_transformation.test", "step.0.width", "400"),
+					resource.TestCheckResourceAttr("cloudinary_named_transformation.test", "transformation", "c_fill,g_auto,w_400/e_sharpen"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccNamedTransformationResourceStepConfig("800"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_named_transformation.test", "step.0.width", "800"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccNamedTransformationResourceStepConfig(width string) string {
+	return fmt.Sprintf(`
+resource "cloudinary_named_transformation" "test" {
+  name = "terraform_acc_test_step"
+
+  step {
+    crop    = "fill"
+    gravity = "auto"
+    width   = %[1]q
+  }
+
+  step {
+    effect = "sharpen"
+  }
+}
+`, width)
+}
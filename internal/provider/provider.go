@@ -21,6 +21,10 @@ type provider struct {
 	// that the provider was previously configured.
 	configured bool
 
+	// chunkedUploadThreshold is the size in bytes above which files are
+	// uploaded in chunks.
+	chunkedUploadThreshold int64
+
 	// version is set to the provider version on release, "dev" when the
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
@@ -29,10 +33,12 @@ type provider struct {
 
 // providerData can be used to store data from the Terraform configuration.
 type providerData struct {
-	APIKey        types.String `tfsdk:"api_key"`
-	APISecret     types.String `tfsdk:"api_secret"`
-	CloudName     types.String `tfsdk:"cloud_name"`
-	CloudinaryURL types.String `tfsdk:"cloudinary_url"`
+	APIKey                 types.String `tfsdk:"api_key"`
+	APISecret              types.String `tfsdk:"api_secret"`
+	ChunkedUploadThreshold types.Int64  `tfsdk:"chunked_upload_threshold"`
+	CloudName              types.String `tfsdk:"cloud_name"`
+	CloudinaryURL          types.String `tfsdk:"cloudinary_url"`
+	UploadChunkSize        types.Int64  `tfsdk:"upload_chunk_size"`
 }
 
 func (p *provider) Configure(ctx context.Context, req tfsdk.ConfigureProviderRequest, resp *tfsdk.ConfigureProviderResponse) {
@@ -121,20 +127,58 @@ func (p *provider) Configure(ctx context.Context, req tfsdk.ConfigureProviderReq
 		return
 	}
 
+	if data.UploadChunkSize.Unknown || data.ChunkedUploadThreshold.Unknown {
+		resp.Diagnostics.AddWarning(
+			"Unable to create client",
+			"Cannot use unknown value as upload_chunk_size or chunked_upload_threshold",
+		)
+		return
+	}
+
+	if !data.UploadChunkSize.Null {
+		cld.Config.API.ChunkSize = data.UploadChunkSize.Value
+		cld.Upload.Config.API.ChunkSize = data.UploadChunkSize.Value
+	}
+
+	p.chunkedUploadThreshold = defaultChunkedUploadThreshold
+	if !data.ChunkedUploadThreshold.Null {
+		p.chunkedUploadThreshold = data.ChunkedUploadThreshold.Value
+	}
+
 	p.client = cld
 	p.configured = true
 }
 
 func (p *provider) GetResources(ctx context.Context) (map[string]tfsdk.ResourceType, diag.Diagnostics) {
 	return map[string]tfsdk.ResourceType{
-		"cloudinary_upload_mapping": uploadMappingResourceType{},
+		"cloudinary_access_mode":               accessModeResourceType{},
+		"cloudinary_asset":                     assetResourceType{},
+		"cloudinary_asset_context":             assetContextResourceType{},
+		"cloudinary_asset_metadata":            assetMetadataResourceType{},
+		"cloudinary_asset_moderation":          assetModerationResourceType{},
+		"cloudinary_asset_relation":            assetRelationResourceType{},
+		"cloudinary_asset_restore":             assetRestoreResourceType{},
+		"cloudinary_asset_tags":                assetTagsResourceType{},
+		"cloudinary_derived_asset":             derivedAssetResourceType{},
+		"cloudinary_folder":                    folderResourceType{},
+		"cloudinary_metadata_datasource_entry": metadataDatasourceEntryResourceType{},
+		"cloudinary_metadata_field":            metadataFieldResourceType{},
+		"cloudinary_metadata_rule":             metadataRuleResourceType{},
+		"cloudinary_named_transformation":      namedTransformationResourceType{},
+		"cloudinary_streaming_profile":         streamingProfileResourceType{},
+		"cloudinary_upload_mapping":            uploadMappingResourceType{},
+		"cloudinary_upload_preset":             uploadPresetResourceType{},
+		"cloudinary_video_streaming":           videoStreamingResourceType{},
 	}, nil
 }
 
 func (p *provider) GetDataSources(ctx context.Context) (map[string]tfsdk.DataSourceType, diag.Diagnostics) {
 	return map[string]tfsdk.DataSourceType{
-		"cloudinary_upload_mapping": uploadMappingDataSourceType{},
-		"cloudinary_usage":          usageDataSourceType{},
+		"cloudinary_asset_versions":    assetVersionsDataSourceType{},
+		"cloudinary_derived_resources": derivedResourcesDataSourceType{},
+		"cloudinary_moderation_queue":  moderationQueueDataSourceType{},
+		"cloudinary_upload_mapping":    uploadMappingDataSourceType{},
+		"cloudinary_usage":             usageDataSourceType{},
 	}, nil
 }
 
@@ -153,6 +197,14 @@ func (p *provider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostic
 				Sensitive: true,
 				Type:      types.StringType,
 			},
+			"chunked_upload_threshold": {
+				MarkdownDescription: "The size in bytes above which local files and inline content are uploaded in chunks. Defaults to `100000000`.",
+				Optional:            true,
+				Type:                types.Int64Type,
+				Validators: []tfsdk.AttributeValidator{
+					int64AtLeastValidator{min: 0},
+				},
+			},
 			"cloud_name": {
 				Computed: true,
 				Optional: true,
@@ -164,6 +216,14 @@ func (p *provider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostic
 				Sensitive: true,
 				Type:      types.StringType,
 			},
+			"upload_chunk_size": {
+				MarkdownDescription: "The size in bytes of the chunks of chunked uploads. Cloudinary requires at least `5000000`. Defaults to `20000000`.",
+				Optional:            true,
+				Type:                types.Int64Type,
+				Validators: []tfsdk.AttributeValidator{
+					int64AtLeastValidator{min: 5000000},
+				},
+			},
 		},
 	}, nil
 }
@@ -0,0 +1,393 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/cloudinary/cloudinary-go/api/admin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type streamingProfileResourceType struct{}
+
+func (t streamingProfileResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Streaming Profile resource. Predefined profiles can be imported but not modified.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"display_name": {
+				MarkdownDescription: "The display name of the streaming profile.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"name": {
+				MarkdownDescription: "The name of the streaming profile.",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"predefined": {
+				MarkdownDescription: "Whether the streaming profile is a built-in profile.",
+				Computed:            true,
+				Type:                types.BoolType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"representation": {
+				MarkdownDescription: "The transformations of the representations, ordered from the highest to the lowest quality. Conflicts with `representation_steps`.",
+				Optional:            true,
+				Type:                types.ListType{ElemType: types.StringType},
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					transformationPlanModifier{},
+				},
+				Validators: []tfsdk.AttributeValidator{
+					transformationValidator{},
+				},
+			},
+		},
+
+		Blocks: map[string]tfsdk.Block{
+			"representation_steps": transformationStepsListBlock("The transformations of the representations described with steps, ordered from the highest to the lowest quality. Conflicts with `representation`."),
+		},
+	}, nil
+}
+
+func (t streamingProfileResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return streamingProfileResource{
+		provider: provider,
+	}, diags
+}
+
+type streamingProfileResourceData struct {
+	DisplayName         types.String                  `tfsdk:"display_name"`
+	ID                  types.String                  `tfsdk:"id"`
+	Name                types.String                  `tfsdk:"name"`
+	Predefined          types.Bool                    `tfsdk:"predefined"`
+	Representation      types.List                    `tfsdk:"representation"`
+	RepresentationSteps []transformationStepsListData `tfsdk:"representation_steps"`
+}
+
+// representations returns the representations in the form expected by the
+// create and update calls, serializing the steps when they are used instead.
+func (data streamingProfileResourceData) representations(ctx context.Context) (admin.StreamingProfileRepresentations, diag.Diagnostics) {
+	transformations, diags := expandTransformationList(ctx, data.Representation, data.RepresentationSteps)
+
+	representations := make(admin.StreamingProfileRepresentations, 0, len(transformations))
+	for _, t := range transformations {
+		representations = append(representations, admin.RawStreamingProfileRepresentation{Transformation: t})
+	}
+
+	return representations, diags
+}
+
+type streamingProfileResource struct {
+	provider provider
+}
+
+func (r streamingProfileResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data streamingProfileResourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Representation.Null && len(data.RepresentationSteps) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("representation"),
+			"Conflicting Attributes",
+			"Only one of representation and representation_steps can be set.",
+		)
+	}
+
+	if data.Representation.Null && len(data.RepresentationSteps) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("representation"),
+			"Missing Attribute",
+			"One of representation or representation_steps must be set.",
+		)
+	}
+}
+
+func (r streamingProfileResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	// Nothing to check on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state, plan streamingProfileResourceData
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stepsChanged := (len(plan.RepresentationSteps) > 0 || len(state.RepresentationSteps) > 0) && !reflect.DeepEqual(plan.RepresentationSteps, state.RepresentationSteps)
+
+	if state.Predefined.Value && (!plan.DisplayName.Equal(state.DisplayName) || !plan.Representation.Equal(state.Representation) || stepsChanged) {
+		resp.Diagnostics.AddError(
+			"Predefined Streaming Profile",
+			fmt.Sprintf("The streaming profile %q is a predefined profile and cannot be modified. Create a custom streaming profile instead.", state.Name.Value),
+		)
+	}
+}
+
+func (r streamingProfileResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data streamingProfileResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Name
+
+	list, err := r.provider.client.Admin.ListStreamingProfiles(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to list streaming profiles, got error: %s", err),
+		)
+		return
+	}
+
+	for _, profile := range list.Data {
+		if profile.Name == data.Name.Value && profile.Predefined {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Predefined Streaming Profile",
+				fmt.Sprintf("The streaming profile %q is a predefined profile and cannot be created or modified. Choose another name.", profile.Name),
+			)
+			return
+		}
+	}
+
+	representations, diags := data.representations(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := admin.CreateStreamingProfileParams{
+		Name:            data.Name.Value,
+		DisplayName:     data.DisplayName.Value,
+		Representations: representations,
+	}
+
+	res, err := r.provider.client.Admin.CreateStreamingProfile(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create streaming profile, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create streaming profile, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r streamingProfileResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data streamingProfileResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Name
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r streamingProfileResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data streamingProfileResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Name
+
+	representations, diags := data.representations(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := admin.UpdateStreamingProfileParams{
+		Name:            data.Name.Value,
+		DisplayName:     data.DisplayName.Value,
+		Representations: representations,
+	}
+
+	res, err := r.provider.client.Admin.UpdateStreamingProfile(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update streaming profile, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update streaming profile, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r streamingProfileResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data streamingProfileResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Built-in profiles cannot be deleted, they are only removed from the
+	// state.
+	if data.Predefined.Value {
+		resp.Diagnostics.AddWarning(
+			"Predefined Streaming Profile",
+			fmt.Sprintf("The streaming profile %q is a predefined profile and was only removed from the Terraform state.", data.Name.Value),
+		)
+		return
+	}
+
+	params := admin.DeleteStreamingProfileParams{
+		Name: data.Name.Value,
+	}
+
+	res, err := r.provider.client.Admin.DeleteStreamingProfile(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete streaming profile, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete streaming profile, got error: %s", res.Error.Message),
+		)
+		return
+	}
+}
+
+func (r streamingProfileResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+// read refreshes data with the streaming profile stored by Cloudinary.
+func (r streamingProfileResource) read(ctx context.Context, data *streamingProfileResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	params := admin.GetStreamingProfileParams{
+		Name: data.Name.Value,
+	}
+
+	res, err := r.provider.client.Admin.GetStreamingProfile(ctx, params)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read streaming profile, got error: %s", err),
+		)
+		return diags
+	}
+
+	if res.Error.Message != "" {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read streaming profile, got error: %s", res.Error.Message),
+		)
+		return diags
+	}
+
+	representations := make([]string, 0, len(res.Data.Representations))
+	for _, representation := range res.Data.Representations {
+		representations = append(representations, flattenTransformation(representation.Transformation))
+	}
+
+	data.DisplayName = types.String{Value: res.Data.DisplayName}
+	data.Predefined = types.Bool{Value: res.Data.Predefined}
+
+	if len(data.RepresentationSteps) > 0 {
+		data.RepresentationSteps = semanticTransformationStepsList(ctx, data.RepresentationSteps, representations)
+	} else {
+		data.Representation = semanticTransformationList(data.Representation, representations)
+		data.RepresentationSteps = []transformationStepsListData{}
+	}
+
+	return diags
+}
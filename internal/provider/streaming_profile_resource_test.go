@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccStreamingProfileResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccStreamingProfileResourceConfig("Terraform Acceptance Test"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_streaming_profile.test", "name", "terraform_acc_test"),
+					resource.TestCheckResourceAttr("cloudinary_streaming_profile.test", "display_name", "Terraform Acceptance Test"),
+					resource.TestCheckResourceAttr("cloudinary_streaming_profile.test", "predefined", "false"),
+					resource.TestCheckResourceAttr("cloudinary_streaming_profile.test", "representation.#", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "cloudinary_streaming_profile.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccStreamingProfileResourceConfig("Terraform Acceptance Test (updated)"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_streaming_profile.test", "display_name", "Terraform Acceptance Test (updated)"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccStreamingProfileResource_predefined(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "cloudinary_streaming_profile" "test" {
+  name           = "hd"
+  representation = ["c_limit,h_1080,w_1920"]
+}
+`,
+				ExpectError: regexp.MustCompile("predefined profile"),
+			},
+		},
+	})
+}
+
+func TestAccStreamingProfileResource_steps(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "cloudinary_streaming_profile" "test" {
+  name = "terraform_acc_test_steps"
+
+  representation_steps {
+    step {
+      crop   = "limit"
+      width  = "1280"
+      height = "720"
+
+      raw_transformation = "br_3m,vc_h264:main:3.1"
+    }
+  }
+
+  representation_steps {
+    step {
+      crop   = "limit"
+      width  = "640"
+      height = "360"
+
+      raw_transformation = "br_800k,vc_h264:baseline:3.0"
+    }
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_streaming_profile.test", "representation_steps.#", "2"),
+					resource.TestCheckResourceAttr("cloudinary_streaming_profile.test", "representation_steps.1.step.0.width", "640"),
+					resource.TestCheckNoResourceAttr("cloudinary_streaming_profile.test", "representation.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccStreamingProfileResourceConfig(displayName string) string {
+	return fmt.Sprintf(`
+resource "cloudinary_streaming_profile" "test" {
+  name         = "terraform_acc_test"
+  display_name = %[1]q
+
+  representation = [
+    "br_3m,c_limit,h_720,vc_h264:main:3.1,w_1280",
+    "br_800k,c_limit,h_360,vc_h264:baseline:3.0,w_640",
+  ]
+}
+`, displayName)
+}
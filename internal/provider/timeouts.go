@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultCreateTimeout is how long the creation of a resource waits for
+// Cloudinary when the create timeout is not configured.
+const defaultCreateTimeout = 30 * time.Minute
+
+// timeoutsData is the timeouts attribute of resources whose creation waits
+// for Cloudinary to finish processing in the background.
+type timeoutsData struct {
+	Create types.String `tfsdk:"create"`
+}
+
+// timeoutsAttribute returns the timeouts attribute.
+func timeoutsAttribute() tfsdk.Attribute {
+	return tfsdk.Attribute{
+		MarkdownDescription: "The timeouts of the operations on the resource.",
+		Attributes: tfsdk.SingleNestedAttributes(
+			map[string]tfsdk.Attribute{
+				"create": {
+					MarkdownDescription: "How long to wait for the creation to complete, as a duration string such as `45m` (default `30m`).",
+					Optional:            true,
+					Type:                types.StringType,
+					Validators: []tfsdk.AttributeValidator{
+						durationValidator{},
+					},
+				},
+			},
+		),
+		Optional: true,
+	}
+}
+
+// createTimeout returns the configured create timeout, or the default one.
+// The value is checked by durationValidator beforehand.
+func (data *timeoutsData) createTimeout() time.Duration {
+	if data == nil || data.Create.Null || data.Create.Unknown {
+		return defaultCreateTimeout
+	}
+
+	d, err := time.ParseDuration(data.Create.Value)
+	if err != nil {
+		return defaultCreateTimeout
+	}
+
+	return d
+}
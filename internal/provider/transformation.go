@@ -0,0 +1,621 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// transformationParams maps the long parameter names used by the Admin API
+// when it returns transformations in their object form to the short keys of
+// Cloudinary's transformation URL syntax.
+var transformationParams = map[string]string{
+	"angle":             "a",
+	"aspect_ratio":      "ar",
+	"audio_codec":       "ac",
+	"audio_frequency":   "af",
+	"background":        "b",
+	"bit_rate":          "br",
+	"border":            "bo",
+	"color":             "co",
+	"color_space":       "cs",
+	"crop":              "c",
+	"default_image":     "d",
+	"delay":             "dl",
+	"density":           "dn",
+	"dpr":               "dpr",
+	"duration":          "du",
+	"effect":            "e",
+	"end_offset":        "eo",
+	"fetch_format":      "f",
+	"flags":             "fl",
+	"format":            "f",
+	"fps":               "fps",
+	"gravity":           "g",
+	"height":            "h",
+	"if":                "if",
+	"keyframe_interval": "ki",
+	"opacity":           "o",
+	"overlay":           "l",
+	"page":              "pg",
+	"prefix":            "p",
+	"quality":           "q",
+	"radius":            "r",
+	"start_offset":      "so",
+	"streaming_profile": "sp",
+	"transformation":    "t",
+	"underlay":          "u",
+	"video_codec":       "vc",
+	"width":             "w",
+	"x":                 "x",
+	"y":                 "y",
+	"zoom":              "z",
+}
+
+// transformationParam is a single key_value parameter of a transformation
+// component.
+type transformationParam struct {
+	Key   string
+	Value string
+}
+
+func (p transformationParam) String() string {
+	if p.Value == "" {
+		return p.Key
+	}
+
+	return p.Key + "_" + p.Value
+}
+
+// parseTransformation splits a transformation string into its chained
+// components and their parameters.
+func parseTransformation(s string) [][]transformationParam {
+	var components [][]transformationParam
+
+	for _, c := range strings.Split(s, "/") {
+		var params []transformationParam
+
+		for _, p := range strings.Split(c, ",") {
+			if p == "" {
+				continue
+			}
+
+			kv := strings.SplitN(p, "_", 2)
+			param := transformationParam{Key: kv[0]}
+			if len(kv) == 2 {
+				param.Value = kv[1]
+			}
+			params = append(params, param)
+		}
+
+		if len(params) > 0 {
+			components = append(components, params)
+		}
+	}
+
+	return components
+}
+
+// formatTransformationComponent joins the parameters of a single component
+// in the order used by Cloudinary: the condition first, then user defined
+// variables and the remaining parameters sorted by key. raw is appended
+// verbatim.
+func formatTransformationComponent(params []transformationParam, raw string) string {
+	var cond, vars, rest []string
+
+	for _, p := range params {
+		switch {
+		case p.Key == "if":
+			cond = append(cond, p.String())
+		case strings.HasPrefix(p.Key, "$"):
+			vars = append(vars, p.String())
+		default:
+			rest = append(rest, p.String())
+		}
+	}
+
+	sort.Strings(vars)
+	sort.Strings(rest)
+
+	s := append(append(cond, vars...), rest...)
+	if raw != "" {
+		s = append(s, raw)
+	}
+
+	return strings.Join(s, ",")
+}
+
+// transformationDefaults are parameters which have the default value and
+// are therefore dropped by Cloudinary when it normalizes a transformation.
+var transformationDefaults = map[string]bool{
+	"a_0":      true,
+	"dpr_1":    true,
+	"dpr_1.0":  true,
+	"g_center": true,
+	"o_100":    true,
+}
+
+// transformationAliases maps parameters to the equivalent form stored by
+// Cloudinary.
+var transformationAliases = map[string]string{
+	"q_auto:good": "q_auto",
+}
+
+// normalizeTransformation returns the normalized form of a transformation
+// string: parameters are ordered the way Cloudinary orders them, defaults and
+// empty components are dropped, so that equivalent transformations have the
+// same normalized form.
+func normalizeTransformation(s string) string {
+	var components []string
+
+	for _, params := range parseTransformation(s) {
+		normalized := make([]transformationParam, 0, len(params))
+
+		for _, p := range params {
+			if transformationDefaults[p.String()] {
+				continue
+			}
+
+			if alias, ok := transformationAliases[p.String()]; ok {
+				p = parseTransformation(alias)[0][0]
+			}
+
+			normalized = append(normalized, p)
+		}
+
+		if c := formatTransformationComponent(normalized, ""); c != "" {
+			components = append(components, c)
+		}
+	}
+
+	return strings.Join(components, "/")
+}
+
+// transformationEqual reports whether two transformation strings are
+// equivalent.
+func transformationEqual(a, b string) bool {
+	return normalizeTransformation(a) == normalizeTransformation(b)
+}
+
+// semanticTransformation returns the transformation to store for the value
+// returned by Cloudinary. The current value is kept when it is equivalent to
+// the remote one, so that the normalization done by Cloudinary never shows
+// up as a difference in plans.
+func semanticTransformation(current types.String, remote string) types.String {
+	if !current.Null && !current.Unknown && transformationEqual(current.Value, remote) {
+		return current
+	}
+
+	return types.String{Value: remote}
+}
+
+// semanticTransformationList is semanticTransformation for a list of
+// transformations, compared element by element.
+func semanticTransformationList(current types.List, remote []string) types.List {
+	list := types.List{ElemType: types.StringType}
+
+	for i, r := range remote {
+		var c types.String
+		if !current.Null && !current.Unknown && i < len(current.Elems) {
+			c, _ = current.Elems[i].(types.String)
+		} else {
+			c = types.String{Null: true}
+		}
+
+		list.Elems = append(list.Elems, semanticTransformation(c, r))
+	}
+
+	if len(list.Elems) == 0 {
+		list.Null = true
+	}
+
+	return list
+}
+
+// transformationPlanModifier keeps the prior value of a transformation
+// string, or list of transformation strings, when the planned value is
+// equivalent to it, so that rewriting a transformation into an equivalent
+// form never shows up as a difference in plans. Terraform accepts the prior
+// value in place of an equivalent configured value.
+type transformationPlanModifier struct{}
+
+func (m transformationPlanModifier) Description(ctx context.Context) string {
+	return "Keeps the prior value when the planned transformation is equivalent to it."
+}
+
+func (m transformationPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m transformationPlanModifier) Modify(ctx context.Context, req tfsdk.ModifyAttributePlanRequest, resp *tfsdk.ModifyAttributePlanResponse) {
+	if req.AttributeState == nil || resp.AttributePlan == nil {
+		return
+	}
+
+	switch plan := resp.AttributePlan.(type) {
+	case types.String:
+		state, ok := req.AttributeState.(types.String)
+		if ok && transformationValueEqual(plan, state) {
+			resp.AttributePlan = state
+		}
+	case types.List:
+		state, ok := req.AttributeState.(types.List)
+		if !ok || plan.Null || plan.Unknown || state.Null || state.Unknown || len(plan.Elems) != len(state.Elems) {
+			return
+		}
+
+		for i := range plan.Elems {
+			p, _ := plan.Elems[i].(types.String)
+			s, _ := state.Elems[i].(types.String)
+			if !transformationValueEqual(p, s) {
+				return
+			}
+		}
+
+		resp.AttributePlan = state
+	}
+}
+
+// transformationValueEqual reports whether two known transformation values
+// are equivalent.
+func transformationValueEqual(a, b types.String) bool {
+	if a.Null || a.Unknown || b.Null || b.Unknown {
+		return false
+	}
+
+	return transformationEqual(a.Value, b.Value)
+}
+
+// semanticTransformationSteps returns the steps to store for the
+// transformation returned by Cloudinary, keeping the current steps when they
+// are equivalent.
+func semanticTransformationSteps(ctx context.Context, current []transformationStepData, remote string) []transformationStepData {
+	if s, diags := expandTransformationSteps(ctx, current); !diags.HasError() && transformationEqual(s, remote) {
+		return current
+	}
+
+	return flattenTransformationSteps(remote)
+}
+
+// flattenTransformation converts a transformation as returned by the Admin
+// API into the transformation URL syntax. The API returns either a string,
+// a single object of long parameter names or a list of such objects (one
+// per chained component).
+func flattenTransformation(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		components := make([]string, 0, len(v))
+		for _, c := range v {
+			if s := flattenTransformation(c); s != "" {
+				components = append(components, s)
+			}
+		}
+		return strings.Join(components, "/")
+	case []map[string]interface{}:
+		components := make([]string, 0, len(v))
+		for _, c := range v {
+			if s := flattenTransformation(c); s != "" {
+				components = append(components, s)
+			}
+		}
+		return strings.Join(components, "/")
+	case map[string]interface{}:
+		var raw string
+		params := make([]transformationParam, 0, len(v))
+		for name, value := range v {
+			if name == "raw_transformation" {
+				raw = fmt.Sprint(value)
+				continue
+			}
+
+			key, ok := transformationParams[name]
+			if !ok {
+				// user defined variables ($name) and unknown parameters
+				key = name
+			}
+
+			params = append(params, transformationParam{Key: key, Value: flattenTransformationValue(value)})
+		}
+		return formatTransformationComponent(params, raw)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// flattenTransformationValue returns the value of a transformation parameter
+// as a string, where lists are joined with dots.
+func flattenTransformationValue(v interface{}) string {
+	values, ok := v.([]interface{})
+	if !ok {
+		return flattenValue(v)
+	}
+
+	flattened := make([]string, 0, len(values))
+	for _, e := range values {
+		flattened = append(flattened, flattenTransformationValue(e))
+	}
+	return strings.Join(flattened, ".")
+}
+
+// transformationStepData is a single chained component of a transformation
+// described with typed attributes instead of a transformation string.
+type transformationStepData struct {
+	Crop              types.String `tfsdk:"crop"`
+	Effect            types.String `tfsdk:"effect"`
+	Format            types.String `tfsdk:"format"`
+	Gravity           types.String `tfsdk:"gravity"`
+	Height            types.String `tfsdk:"height"`
+	If                types.String `tfsdk:"if"`
+	Overlay           types.String `tfsdk:"overlay"`
+	Quality           types.String `tfsdk:"quality"`
+	RawTransformation types.String `tfsdk:"raw_transformation"`
+	Variables         types.Map    `tfsdk:"variables"`
+	Width             types.String `tfsdk:"width"`
+}
+
+// fields returns the typed parameters of the step keyed by their short
+// transformation key.
+func (s *transformationStepData) fields() map[string]*types.String {
+	return map[string]*types.String{
+		"c":  &s.Crop,
+		"e":  &s.Effect,
+		"f":  &s.Format,
+		"g":  &s.Gravity,
+		"h":  &s.Height,
+		"if": &s.If,
+		"l":  &s.Overlay,
+		"q":  &s.Quality,
+		"w":  &s.Width,
+	}
+}
+
+// transformationStepsBlock returns the schema of a list of transformation
+// steps, an alternative to a raw transformation string.
+func transformationStepsBlock(description string) tfsdk.Block {
+	return tfsdk.Block{
+		MarkdownDescription: description,
+		NestingMode:         tfsdk.BlockNestingModeList,
+		Attributes: map[string]tfsdk.Attribute{
+			"crop": {
+				MarkdownDescription: "The crop mode (`c_`), e.g. `fill`.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					transformationParamValidator{key: "c"},
+				},
+			},
+			"effect": {
+				MarkdownDescription: "The effect (`e_`), e.g. `sharpen:100`.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					transformationParamValidator{key: "e"},
+				},
+			},
+			"format": {
+				MarkdownDescription: "The delivery format (`f_`), e.g. `auto`.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					transformationParamValidator{key: "f"},
+				},
+			},
+			"gravity": {
+				MarkdownDescription: "The gravity (`g_`), e.g. `auto`.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					transformationParamValidator{key: "g"},
+				},
+			},
+			"height": {
+				MarkdownDescription: "The height (`h_`) in pixels, a relative value or an expression.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					transformationParamValidator{key: "h"},
+				},
+			},
+			"if": {
+				MarkdownDescription: "The condition (`if_`) of the step, e.g. `w_gt_1000`. Use `else` or `end` to continue or close a conditional block.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					transformationParamValidator{key: "if"},
+				},
+			},
+			"overlay": {
+				MarkdownDescription: "The overlay (`l_`), e.g. `logo` or `text:Arial_20:Hello`.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					transformationParamValidator{key: "l"},
+				},
+			},
+			"quality": {
+				MarkdownDescription: "The quality (`q_`), e.g. `auto` or `80`.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					transformationParamValidator{key: "q"},
+				},
+			},
+			"raw_transformation": {
+				MarkdownDescription: "Additional parameters in transformation syntax appended to the step.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					transformationValidator{component: true},
+				},
+			},
+			"variables": {
+				MarkdownDescription: "The user defined variables (`$name_value`) keyed by name without the leading `$`.",
+				Optional:            true,
+				Type:                types.MapType{ElemType: types.StringType},
+				Validators: []tfsdk.AttributeValidator{
+					transformationVariablesValidator{},
+				},
+			},
+			"width": {
+				MarkdownDescription: "The width (`w_`) in pixels, a relative value or an expression.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					transformationParamValidator{key: "w"},
+				},
+			},
+		},
+	}
+}
+
+// expandTransformationSteps serializes transformation steps to the
+// transformation URL syntax.
+func expandTransformationSteps(ctx context.Context, steps []transformationStepData) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	components := make([]string, 0, len(steps))
+
+	for _, step := range steps {
+		var params []transformationParam
+
+		for key, v := range step.fields() {
+			if v.Null || v.Unknown || v.Value == "" {
+				continue
+			}
+			params = append(params, transformationParam{Key: key, Value: v.Value})
+		}
+
+		if !step.Variables.Null && !step.Variables.Unknown {
+			vars := map[string]string{}
+			diags.Append(step.Variables.ElementsAs(ctx, &vars, false)...)
+
+			for name, value := range vars {
+				params = append(params, transformationParam{Key: "$" + strings.TrimPrefix(name, "$"), Value: value})
+			}
+		}
+
+		if c := formatTransformationComponent(params, step.RawTransformation.Value); c != "" {
+			components = append(components, c)
+		}
+	}
+
+	return strings.Join(components, "/"), diags
+}
+
+// flattenTransformationSteps parses a transformation string into steps.
+// Parameters without a typed attribute are kept in raw_transformation.
+func flattenTransformationSteps(s string) []transformationStepData {
+	steps := []transformationStepData{}
+
+	for _, params := range parseTransformation(s) {
+		step := transformationStepData{
+			RawTransformation: types.String{Null: true},
+			Variables:         types.Map{ElemType: types.StringType, Null: true},
+		}
+		fields := step.fields()
+
+		for _, v := range fields {
+			*v = types.String{Null: true}
+		}
+
+		var raw []string
+
+		for _, p := range params {
+			if strings.HasPrefix(p.Key, "$") {
+				if step.Variables.Null {
+					step.Variables = types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}}
+				}
+				step.Variables.Elems[strings.TrimPrefix(p.Key, "$")] = types.String{Value: p.Value}
+				continue
+			}
+
+			if v, ok := fields[p.Key]; ok && v.Null {
+				*v = types.String{Value: p.Value}
+				continue
+			}
+
+			raw = append(raw, p.String())
+		}
+
+		if len(raw) > 0 {
+			step.RawTransformation = types.String{Value: strings.Join(raw, ",")}
+		}
+
+		steps = append(steps, step)
+	}
+
+	return steps
+}
+
+// transformationStepsListData is a transformation of a list of
+// transformations described with steps instead of a transformation string.
+type transformationStepsListData struct {
+	Steps []transformationStepData `tfsdk:"step"`
+}
+
+// transformationStepsListBlock returns the schema of a list of
+// transformations described with steps, an alternative to a list of
+// transformation strings.
+func transformationStepsListBlock(description string) tfsdk.Block {
+	return tfsdk.Block{
+		MarkdownDescription: description,
+		NestingMode:         tfsdk.BlockNestingModeList,
+		Blocks: map[string]tfsdk.Block{
+			"step": transformationStepsBlock("The chained components of the transformation."),
+		},
+	}
+}
+
+// expandTransformationList returns the transformation strings of a list of
+// transformations, or of the steps when they are used instead.
+func expandTransformationList(ctx context.Context, list types.List, steps []transformationStepsListData) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if len(steps) == 0 {
+		var transformations []string
+
+		if !list.Null && !list.Unknown {
+			diags.Append(list.ElementsAs(ctx, &transformations, false)...)
+		}
+
+		return transformations, diags
+	}
+
+	transformations := make([]string, 0, len(steps))
+	for _, s := range steps {
+		t, d := expandTransformationSteps(ctx, s.Steps)
+		diags.Append(d...)
+		transformations = append(transformations, t)
+	}
+
+	return transformations, diags
+}
+
+// semanticTransformationStepsList is semanticTransformationSteps for a list
+// of transformations, compared element by element.
+func semanticTransformationStepsList(ctx context.Context, current []transformationStepsListData, remote []string) []transformationStepsListData {
+	list := make([]transformationStepsListData, 0, len(remote))
+
+	for i, r := range remote {
+		var steps []transformationStepData
+		if i < len(current) {
+			steps = semanticTransformationSteps(ctx, current[i].Steps, r)
+		} else {
+			steps = flattenTransformationSteps(r)
+		}
+
+		list = append(list, transformationStepsListData{Steps: steps})
+	}
+
+	return list
+}
@@ -0,0 +1,292 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFlattenTransformation(t *testing.T) {
+	tests := map[string]struct {
+		in   interface{}
+		want string
+	}{
+		"string": {
+			in:   "c_fill,w_100",
+			want: "c_fill,w_100",
+		},
+		"object": {
+			in: map[string]interface{}{
+				"width":  float64(100),
+				"crop":   "fill",
+				"$ratio": "0.5",
+				"if":     "w_gt_100",
+			},
+			want: "if_w_gt_100,$ratio_0.5,c_fill,w_100",
+		},
+		"chain": {
+			in: []interface{}{
+				map[string]interface{}{"crop": "fill", "width": float64(400), "gravity": "auto"},
+				map[string]interface{}{"effect": "sharpen", "raw_transformation": "fl_keep_iptc"},
+			},
+			want: "c_fill,g_auto,w_400/e_sharpen,fl_keep_iptc",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := flattenTransformation(tt.in); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransformationSteps(t *testing.T) {
+	ctx := context.Background()
+
+	steps := []transformationStepData{
+		{
+			Crop:    types.String{Value: "fill"},
+			Gravity: types.String{Value: "auto"},
+			Width:   types.String{Value: "400"},
+			Variables: types.Map{
+				ElemType: types.StringType,
+				Elems:    map[string]attr.Value{"w": types.String{Value: "400"}},
+			},
+		},
+		{
+			If:                types.String{Value: "w_gt_1000"},
+			Effect:            types.String{Value: "sharpen"},
+			RawTransformation: types.String{Value: "fl_keep_iptc"},
+			Variables:         types.Map{ElemType: types.StringType, Null: true},
+		},
+		{
+			If:        types.String{Value: "end"},
+			Variables: types.Map{ElemType: types.StringType, Null: true},
+		},
+	}
+
+	s, diags := expandTransformationSteps(ctx, steps)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	want := "$w_400,c_fill,g_auto,w_400/if_w_gt_1000,e_sharpen,fl_keep_iptc/if_end"
+	if s != want {
+		t.Fatalf("got %q, want %q", s, want)
+	}
+
+	flattened := flattenTransformationSteps(s)
+	if len(flattened) != 3 {
+		t.Fatalf("got %d steps, want 3", len(flattened))
+	}
+
+	if got := flattened[0].Variables.Elems["w"]; !got.Equal(types.String{Value: "400"}) {
+		t.Errorf("got variable %v, want 400", got)
+	}
+
+	if got := flattened[1].RawTransformation.Value; got != "fl_keep_iptc" {
+		t.Errorf("got raw_transformation %q, want fl_keep_iptc", got)
+	}
+
+	if got := flattened[2].If.Value; got != "end" {
+		t.Errorf("got if %q, want end", got)
+	}
+
+	roundtrip, diags := expandTransformationSteps(ctx, flattened)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if roundtrip != want {
+		t.Errorf("got %q, want %q", roundtrip, want)
+	}
+}
+
+func TestNormalizeTransformation(t *testing.T) {
+	tests := map[string]struct {
+		a, b  string
+		equal bool
+	}{
+		"parameter order": {
+			a:     "w_100,h_200",
+			b:     "h_200,w_100",
+			equal: true,
+		},
+		"chained components": {
+			a:     "w_400,c_fill/e_sharpen",
+			b:     "c_fill,w_400/e_sharpen",
+			equal: true,
+		},
+		"dropped defaults": {
+			a:     "c_fill,g_center,w_100,q_auto:good",
+			b:     "c_fill,q_auto,w_100",
+			equal: true,
+		},
+		"empty components": {
+			a:     "c_fill,w_100//",
+			b:     "c_fill,w_100",
+			equal: true,
+		},
+		"condition first": {
+			a:     "c_scale,w_500,if_w_gt_1000",
+			b:     "if_w_gt_1000,c_scale,w_500",
+			equal: true,
+		},
+		"component order": {
+			a:     "c_fill,w_100/e_sharpen",
+			b:     "e_sharpen/c_fill,w_100",
+			equal: false,
+		},
+		"different values": {
+			a:     "w_100",
+			b:     "w_200",
+			equal: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := transformationEqual(tt.a, tt.b); got != tt.equal {
+				t.Errorf("transformationEqual(%q, %q) = %t, want %t", tt.a, tt.b, got, tt.equal)
+			}
+		})
+	}
+}
+
+func TestSemanticTransformation(t *testing.T) {
+	current := types.String{Value: "w_100,h_200"}
+
+	if got := semanticTransformation(current, "h_200,w_100"); !got.Equal(current) {
+		t.Errorf("got %v, want %v", got, current)
+	}
+
+	want := types.String{Value: "h_300,w_100"}
+	if got := semanticTransformation(current, "h_300,w_100"); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestTransformationPlanModifier(t *testing.T) {
+	ctx := context.Background()
+
+	list := func(values ...string) types.List {
+		l := types.List{ElemType: types.StringType}
+		for _, v := range values {
+			l.Elems = append(l.Elems, types.String{Value: v})
+		}
+		return l
+	}
+
+	tests := map[string]struct {
+		state attr.Value
+		plan  attr.Value
+		want  attr.Value
+	}{
+		"equivalent string": {
+			state: types.String{Value: "w_100,h_200"},
+			plan:  types.String{Value: "h_200,w_100"},
+			want:  types.String{Value: "w_100,h_200"},
+		},
+		"different string": {
+			state: types.String{Value: "w_100,h_200"},
+			plan:  types.String{Value: "h_300,w_100"},
+			want:  types.String{Value: "h_300,w_100"},
+		},
+		"unknown string": {
+			state: types.String{Value: "w_100"},
+			plan:  types.String{Unknown: true},
+			want:  types.String{Unknown: true},
+		},
+		"no state": {
+			state: types.String{Null: true},
+			plan:  types.String{Value: "w_100"},
+			want:  types.String{Value: "w_100"},
+		},
+		"equivalent list": {
+			state: list("c_fill,w_200,h_200", "w_400/webp"),
+			plan:  list("h_200,w_200,c_fill", "w_400/webp"),
+			want:  list("c_fill,w_200,h_200", "w_400/webp"),
+		},
+		"reordered list": {
+			state: list("w_100", "w_200"),
+			plan:  list("w_200", "w_100"),
+			want:  list("w_200", "w_100"),
+		},
+		"longer list": {
+			state: list("w_100"),
+			plan:  list("w_100", "w_200"),
+			want:  list("w_100", "w_200"),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := tfsdk.ModifyAttributePlanRequest{
+				AttributeState: tt.state,
+				AttributePlan:  tt.plan,
+			}
+			resp := tfsdk.ModifyAttributePlanResponse{
+				AttributePlan: tt.plan,
+			}
+
+			transformationPlanModifier{}.Modify(ctx, req, &resp)
+
+			if !resp.AttributePlan.Equal(tt.want) {
+				t.Errorf("plan = %s, want %s", resp.AttributePlan, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransformationStepsList(t *testing.T) {
+	ctx := context.Background()
+
+	steps := []transformationStepsListData{
+		{Steps: flattenTransformationSteps("c_fill,h_300,w_400/e_sharpen")},
+		{Steps: flattenTransformationSteps("q_auto")},
+	}
+
+	got, diags := expandTransformationList(ctx, types.List{ElemType: types.StringType, Null: true}, steps)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if want := []string{"c_fill,h_300,w_400/e_sharpen", "q_auto"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expandTransformationList() = %v, want %v", got, want)
+	}
+
+	list := types.List{ElemType: types.StringType, Elems: []attr.Value{types.String{Value: "w_100"}}}
+
+	got, diags = expandTransformationList(ctx, list, nil)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if want := []string{"w_100"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expandTransformationList() = %v, want %v", got, want)
+	}
+
+	// Equivalent steps are kept, changed ones are parsed again.
+	refreshed := semanticTransformationStepsList(ctx, steps, []string{"w_400,h_300,c_fill/e_sharpen", "q_80", "f_auto"})
+	if len(refreshed) != 3 {
+		t.Fatalf("semanticTransformationStepsList() returned %d transformations, want 3", len(refreshed))
+	}
+
+	if !reflect.DeepEqual(refreshed[0], steps[0]) {
+		t.Errorf("semanticTransformationStepsList()[0] = %v, want %v", refreshed[0], steps[0])
+	}
+
+	if q := refreshed[1].Steps[0].Quality.Value; q != "80" {
+		t.Errorf("semanticTransformationStepsList()[1] quality = %q, want 80", q)
+	}
+
+	if f := refreshed[2].Steps[0].Format.Value; f != "auto" {
+		t.Errorf("semanticTransformationStepsList()[2] format = %q, want auto", f)
+	}
+}
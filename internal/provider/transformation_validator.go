@@ -0,0 +1,338 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// transformationCropModes are the values accepted by the crop (c_) parameter.
+var transformationCropModes = map[string]bool{
+	"auto":         true,
+	"crop":         true,
+	"fill":         true,
+	"fill_pad":     true,
+	"fit":          true,
+	"imagga_crop":  true,
+	"imagga_scale": true,
+	"lfill":        true,
+	"limit":        true,
+	"lpad":         true,
+	"mfit":         true,
+	"mpad":         true,
+	"pad":          true,
+	"scale":        true,
+	"thumb":        true,
+}
+
+// transformationVariables are the predefined variables usable in arithmetic
+// expressions and conditions.
+var transformationVariables = map[string]bool{
+	"ah": true, "ar": true, "aw": true, "ch": true, "cp": true, "cw": true,
+	"dpr": true, "du": true, "fc": true, "h": true, "iar": true, "idu": true,
+	"ih": true, "ilh": true, "ilw": true, "iw": true, "pc": true, "pg": true,
+	"px": true, "py": true, "w": true, "x": true, "y": true,
+}
+
+// transformationOperators are the operators usable in arithmetic
+// expressions and conditions.
+var transformationOperators = map[string]bool{
+	"add": true, "and": true, "div": true, "eq": true, "gt": true, "gte": true,
+	"in": true, "lt": true, "lte": true, "mod": true, "mul": true, "ne": true,
+	"nin": true, "or": true, "pow": true, "sub": true,
+}
+
+var (
+	transformationExtensionRegexp = regexp.MustCompile(`^[a-z0-9]+$`)
+	transformationFormatRegexp    = regexp.MustCompile(`^(?:[a-z0-9]+|auto(?::[a-z]+)?)$`)
+	transformationVariableRegexp  = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*$`)
+)
+
+// transformationValueValidators check the value of the parameters with a
+// well defined syntax. Every other known parameter only needs a value.
+var transformationValueValidators = map[string]func(string) bool{
+	"a": func(v string) bool {
+		for _, part := range strings.Split(v, ".") {
+			switch part {
+			case "auto_left", "auto_right", "exif", "hflip", "ignore", "vflip":
+				continue
+			}
+			if !isTransformationExpression(part) {
+				return false
+			}
+		}
+		return true
+	},
+	"ar": func(v string) bool {
+		parts := strings.Split(v, ":")
+		if len(parts) == 2 {
+			return isTransformationNumber(parts[0]) && isTransformationNumber(parts[1])
+		}
+		return isTransformationExpression(v)
+	},
+	"c": func(v string) bool {
+		return transformationCropModes[v]
+	},
+	"dpr": func(v string) bool {
+		return v == "auto" || isTransformationExpression(v)
+	},
+	"f": func(v string) bool {
+		return transformationFormatRegexp.MatchString(v)
+	},
+	"h": isTransformationDimension,
+	"o": isTransformationExpression,
+	"q": func(v string) bool {
+		if v == "auto" || strings.HasPrefix(v, "auto:") || v == "jpegmini" || strings.HasPrefix(v, "jpegmini:") {
+			return true
+		}
+		parts := strings.Split(v, ":")
+		if len(parts) == 2 {
+			return isTransformationNumber(parts[0]) && isTransformationNumber(parts[1])
+		}
+		return isTransformationExpression(v)
+	},
+	"r": func(v string) bool {
+		if v == "max" {
+			return true
+		}
+		parts := strings.Split(v, ":")
+		if len(parts) > 4 {
+			return false
+		}
+		for _, part := range parts {
+			if !isTransformationExpression(part) {
+				return false
+			}
+		}
+		return true
+	},
+	"w": isTransformationDimension,
+	"x": isTransformationExpression,
+	"y": isTransformationExpression,
+	"z": isTransformationExpression,
+}
+
+// transformationKeys are the known parameter keys of the transformation URL
+// syntax.
+var transformationKeys = func() map[string]bool {
+	keys := map[string]bool{
+		"fn": true,
+		"vs": true,
+	}
+	for _, key := range transformationParams {
+		keys[key] = true
+	}
+	return keys
+}()
+
+func isTransformationNumber(v string) bool {
+	_, err := strconv.ParseFloat(v, 64)
+	return err == nil
+}
+
+// isTransformationExpression reports whether v is a number, a variable or an
+// arithmetic expression such as iw_mul_0.5.
+func isTransformationExpression(v string) bool {
+	if v == "" {
+		return false
+	}
+
+	for _, token := range strings.Split(v, "_") {
+		switch {
+		case isTransformationNumber(token):
+		case strings.HasPrefix(token, "$") && transformationVariableRegexp.MatchString(token[1:]):
+		case transformationVariables[token]:
+		case transformationOperators[token]:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func isTransformationDimension(v string) bool {
+	return v == "auto" || strings.HasPrefix(v, "auto:") || isTransformationExpression(v)
+}
+
+// validateTransformationParam returns an error describing why the parameter
+// is invalid, or nil.
+func validateTransformationParam(p transformationParam) error {
+	if strings.HasPrefix(p.Key, "$") {
+		if !transformationVariableRegexp.MatchString(p.Key[1:]) {
+			return fmt.Errorf("invalid variable name %q", p.Key)
+		}
+	} else if !transformationKeys[p.Key] {
+		return fmt.Errorf("unknown transformation parameter %q", p.Key)
+	}
+
+	if p.Value == "" {
+		return fmt.Errorf("missing value for transformation parameter %q", p.Key)
+	}
+
+	if f, ok := transformationValueValidators[p.Key]; ok && !f(p.Value) {
+		return fmt.Errorf("invalid value %q for transformation parameter %q", p.Value, p.Key)
+	}
+
+	return nil
+}
+
+// validateTransformation returns the problems found in a transformation
+// string. When component is set, the string must be a single component.
+func validateTransformation(s string, component bool) []error {
+	var errs []error
+
+	components := strings.Split(s, "/")
+	if component && len(components) > 1 {
+		return []error{fmt.Errorf("chained components are not allowed here")}
+	}
+
+	for i, c := range components {
+		if c == "" {
+			errs = append(errs, fmt.Errorf("component %d is empty", i+1))
+			continue
+		}
+
+		// The last component can be the extension of the delivered format,
+		// e.g. c_fill,w_400/webp.
+		if !component && i == len(components)-1 && transformationExtensionRegexp.MatchString(c) {
+			continue
+		}
+
+		for _, p := range strings.Split(c, ",") {
+			if p == "" {
+				errs = append(errs, fmt.Errorf("component %d contains an empty parameter", i+1))
+				continue
+			}
+
+			kv := strings.SplitN(p, "_", 2)
+			param := transformationParam{Key: kv[0]}
+			if len(kv) == 2 {
+				param.Value = kv[1]
+			}
+
+			if err := validateTransformationParam(param); err != nil {
+				errs = append(errs, fmt.Errorf("component %d: %w", i+1, err))
+			}
+		}
+	}
+
+	return errs
+}
+
+// transformationValidator validates transformation strings. It accepts
+// string attributes and lists of strings.
+type transformationValidator struct {
+	// component restricts the value to a single transformation component.
+	component bool
+}
+
+func (v transformationValidator) Description(ctx context.Context) string {
+	return "value must be a valid transformation string"
+}
+
+func (v transformationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v transformationValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	switch value := req.AttributeConfig.(type) {
+	case types.String:
+		v.validate(req.AttributePath, value, resp)
+	case types.List:
+		if value.Null || value.Unknown {
+			return
+		}
+		for i, e := range value.Elems {
+			if s, ok := e.(types.String); ok {
+				v.validate(req.AttributePath.AtListIndex(i), s, resp)
+			}
+		}
+	}
+}
+
+func (v transformationValidator) validate(p path.Path, value types.String, resp *tfsdk.ValidateAttributeResponse) {
+	if value.Null || value.Unknown {
+		return
+	}
+
+	for _, err := range validateTransformation(value.Value, v.component) {
+		resp.Diagnostics.AddAttributeError(
+			p,
+			"Invalid Transformation",
+			fmt.Sprintf("The transformation %q is invalid: %s.", value.Value, err),
+		)
+	}
+}
+
+// transformationParamValidator validates the value of a single typed
+// transformation parameter, e.g. the width of a transformation step.
+type transformationParamValidator struct {
+	key string
+}
+
+func (v transformationParamValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be a valid value of the %s_ transformation parameter", v.key)
+}
+
+func (v transformationParamValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be a valid value of the `%s_` transformation parameter", v.key)
+}
+
+func (v transformationParamValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	value, ok := req.AttributeConfig.(types.String)
+	if !ok || value.Null || value.Unknown {
+		return
+	}
+
+	if err := validateTransformationParam(transformationParam{Key: v.key, Value: value.Value}); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid Transformation Parameter",
+			fmt.Sprintf("The value %q is invalid: %s.", value.Value, err),
+		)
+	}
+}
+
+// transformationVariablesValidator validates the names of user defined
+// variables.
+type transformationVariablesValidator struct{}
+
+func (v transformationVariablesValidator) Description(ctx context.Context) string {
+	return "keys must be valid variable names made of letters and digits"
+}
+
+func (v transformationVariablesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v transformationVariablesValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	value, ok := req.AttributeConfig.(types.Map)
+	if !ok || value.Null || value.Unknown {
+		return
+	}
+
+	for name, e := range value.Elems {
+		if err := validateTransformationParam(transformationParam{Key: "$" + strings.TrimPrefix(name, "$"), Value: "0"}); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.AttributePath.AtMapKey(name),
+				"Invalid Transformation Variable",
+				fmt.Sprintf("The variable %q is invalid: %s.", name, err),
+			)
+		}
+
+		if s, ok := e.(types.String); ok && !s.Unknown && s.Value == "" {
+			resp.Diagnostics.AddAttributeError(
+				req.AttributePath.AtMapKey(name),
+				"Invalid Transformation Variable",
+				fmt.Sprintf("The variable %q has no value.", name),
+			)
+		}
+	}
+}
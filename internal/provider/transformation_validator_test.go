@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateTransformation(t *testing.T) {
+	tests := map[string]struct {
+		in        string
+		component bool
+		errors    int
+	}{
+		"valid":                {in: "c_fill,g_auto,h_300,w_400/e_sharpen:100,q_auto:good"},
+		"expression":           {in: "$ratio_0.5,c_scale,w_iw_mul_$ratio"},
+		"conditional":          {in: "if_w_gt_1000/c_scale,w_1000/if_end"},
+		"aspect ratio":         {in: "ar_16:9,c_fill,w_auto:100"},
+		"invalid width":        {in: "w_abc", errors: 1},
+		"unknown parameter":    {in: "cx_fill,w_100", errors: 1},
+		"invalid crop":         {in: "c_stretch", errors: 1},
+		"missing value":        {in: "c_fill,w", errors: 1},
+		"empty component":      {in: "c_fill,w_100//e_sharpen", errors: 1},
+		"empty parameter":      {in: "c_fill,,w_100", errors: 1},
+		"multiple errors":      {in: "w_abc/cx_fill", errors: 2},
+		"invalid variable":     {in: "$1st_10", errors: 1},
+		"component chained":    {in: "c_fill/w_100", component: true, errors: 1},
+		"component single":     {in: "fl_layer_apply,g_north", component: true},
+		"invalid quality":      {in: "q_best", errors: 1},
+		"invalid format":       {in: "f_Web P", errors: 1},
+		"auto format":          {in: "f_auto:image,q_auto"},
+		"auto animated format": {in: "f_auto:animated"},
+		"invalid auto format":  {in: "f_auto:", errors: 1},
+		"named transformation": {in: "t_thumb/e_grayscale"},
+		"format extension":     {in: "c_fill,w_400/webp"},
+		"format only":          {in: "webp"},
+		"invalid extension":    {in: "c_fill,w_400/web_p", errors: 1},
+		"component extension":  {in: "webp", component: true, errors: 1},
+		"extension not last":   {in: "webp/c_fill,w_400", errors: 1},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := validateTransformation(tt.in, tt.component)
+			if len(errs) != tt.errors {
+				t.Errorf("got %d errors (%v), want %d", len(errs), errs, tt.errors)
+			}
+		})
+	}
+}
+
+func TestTransformationValidator(t *testing.T) {
+	ctx := context.Background()
+
+	req := tfsdk.ValidateAttributeRequest{
+		AttributePath: path.Root("eager"),
+		AttributeConfig: types.List{
+			ElemType: types.StringType,
+			Elems: []attr.Value{
+				types.String{Value: "c_fill,w_100"},
+				types.String{Value: "w_abc"},
+				types.String{Unknown: true},
+			},
+		},
+	}
+	resp := tfsdk.ValidateAttributeResponse{}
+
+	transformationValidator{}.Validate(ctx, req, &resp)
+
+	if got := resp.Diagnostics.ErrorsCount(); got != 1 {
+		t.Fatalf("got %d errors, want 1: %v", got, resp.Diagnostics)
+	}
+
+	p := resp.Diagnostics.Errors()[0].(interface{ Path() path.Path }).Path()
+	if want := path.Root("eager").AtListIndex(1); !p.Equal(want) {
+		t.Errorf("got path %s, want %s", p, want)
+	}
+}
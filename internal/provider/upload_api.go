@@ -0,0 +1,381 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go"
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// assetFile is the file of an upload. Exactly one of its fields is set:
+// the path of a local file, the URL of a remote file (or a data URI) which
+// Cloudinary fetches itself, or the content of the file.
+type assetFile struct {
+	Path    string
+	URL     string
+	Content []byte
+}
+
+// uploadArrayKeyRegexp matches the key of an array parameter, e.g.
+// public_ids[] or public_ids[0].
+var uploadArrayKeyRegexp = regexp.MustCompile(`^(.*)\[\d*\]$`)
+
+// signUploadParams returns the parameters of an Upload API call signed the
+// same way the cloudinary-go uploader does, with a new timestamp. The given
+// parameters are left untouched so that they can be signed again. Requests
+// authenticated with an OAuth token are not signed.
+func signUploadParams(client *cloudinary.Cloudinary, unsigned url.Values) (url.Values, error) {
+	cfg := client.Upload.Config
+
+	if cfg.Cloud.OAuthToken != "" {
+		return unsigned, nil
+	}
+
+	if cfg.Cloud.APISecret == "" {
+		return nil, errors.New("must provide API Secret")
+	}
+
+	params := make(url.Values, len(unsigned))
+	for k, v := range unsigned {
+		params[k] = append([]string(nil), v...)
+	}
+
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// Array parameters are signed under their name without the brackets,
+	// with the values of every element joined by commas.
+	signed := url.Values{}
+	for _, k := range keys {
+		switch k {
+		case "file", "cloud_name", "resource_type", "api_key":
+			// not signed
+		default:
+			name := uploadArrayKeyRegexp.ReplaceAllString(k, "$1")
+			signed[name] = append(signed[name], params[k]...)
+		}
+	}
+
+	for k, v := range signed {
+		signed.Set(k, strings.Join(v, ","))
+	}
+
+	signature, err := api.SignParameters(signed, cfg.Cloud.APISecret)
+	if err != nil {
+		return nil, err
+	}
+
+	params.Set("timestamp", signed.Get("timestamp"))
+	params.Set("signature", signature)
+	params.Set("api_key", cfg.Cloud.APIKey)
+
+	return params, nil
+}
+
+// callUploadAPI sends a signed request to an Upload API endpoint whose
+// result the cloudinary-go client does not decode completely. The result is
+// decoded into result, which can embed api.ErrorResp.
+func callUploadAPI(ctx context.Context, client *cloudinary.Cloudinary, path string, params url.Values, result interface{}) error {
+	cfg := client.Upload.Config
+
+	params, err := signUploadParams(client, params)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/%s", api.BaseURL(cfg.API.UploadPrefix), cfg.Cloud.CloudName, path)
+
+	_, b, err := postUpload(ctx, client, endpoint, strings.NewReader(params.Encode()), "application/x-www-form-urlencoded", nil)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, result)
+}
+
+// defaultChunkedUploadThreshold is the size in bytes above which files are
+// uploaded in chunks when the provider does not configure it.
+const defaultChunkedUploadThreshold = 100000000
+
+// maxChunkAttempts is the number of times a chunk is sent before a chunked
+// upload is given up.
+const maxChunkAttempts = 3
+
+// chunkRetryDelay is the delay before a failed chunk is sent again. It grows
+// linearly with the number of attempts.
+var chunkRetryDelay = 2 * time.Second
+
+// uploadAsset uploads a file to the Upload API endpoint of the resource
+// type. Local files and inline content larger than threshold bytes are
+// uploaded in chunks. The result is decoded into result, which can embed
+// api.ErrorResp.
+func uploadAsset(ctx context.Context, client *cloudinary.Cloudinary, threshold int64, resourceType string, file assetFile, params url.Values, result interface{}) error {
+	cfg := client.Upload.Config
+
+	endpoint := fmt.Sprintf("%s/%s/%s", api.BaseURL(cfg.API.UploadPrefix), cfg.Cloud.CloudName, api.BuildPath(resourceType, "upload"))
+
+	var (
+		r    io.ReaderAt
+		name string
+		size int64
+	)
+
+	switch {
+	case file.URL != "":
+		signed, err := signUploadParams(client, params)
+		if err != nil {
+			return err
+		}
+
+		signed.Set("file", file.URL)
+
+		_, b, err := postUpload(ctx, client, endpoint, strings.NewReader(signed.Encode()), "application/x-www-form-urlencoded", nil)
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(b, result)
+	case file.Path != "":
+		f, err := os.Open(file.Path)
+		if err != nil {
+			return err
+		}
+		defer api.DeferredClose(f)
+
+		fi, err := f.Stat()
+		if err != nil {
+			return err
+		}
+
+		r, name, size = f, filepath.Base(file.Path), fi.Size()
+	default:
+		r, name, size = bytes.NewReader(file.Content), "file", int64(len(file.Content))
+	}
+
+	var (
+		b   []byte
+		err error
+	)
+
+	if size > threshold {
+		b, err = uploadChunks(ctx, client, endpoint, params, name, r, size)
+	} else {
+		_, b, err = postMultipart(ctx, client, endpoint, params, name, io.NewSectionReader(r, 0, size), nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, result)
+}
+
+// uploadChunks uploads a file in chunks of the configured chunk size using
+// the chunked upload protocol, and returns the response to the last chunk.
+// A chunk which fails with a network or server error is sent again instead
+// of restarting the whole upload. Every request is signed again, as signed
+// requests expire long before a large upload with retries may end.
+func uploadChunks(ctx context.Context, client *cloudinary.Cloudinary, endpoint string, params url.Values, name string, r io.ReaderAt, size int64) ([]byte, error) {
+	chunkSize := client.Upload.Config.API.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = size
+	}
+
+	uploadID, err := randomUploadID()
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, "starting chunked upload", map[string]interface{}{
+		"upload_id":   uploadID,
+		"total_bytes": size,
+		"chunk_size":  chunkSize,
+	})
+
+	var b []byte
+
+	for start := int64(0); start < size; start += chunkSize {
+		end := start + chunkSize
+		if end > size {
+			end = size
+		}
+
+		header := http.Header{}
+		header.Set("X-Unique-Upload-Id", uploadID)
+		header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end-1, size))
+
+		var status int
+
+		for attempt := 1; ; attempt++ {
+			status, b, err = postMultipart(ctx, client, endpoint, params, name, io.NewSectionReader(r, start, end-start), header)
+			if err == nil && !isRetryableStatus(status) {
+				break
+			}
+
+			if attempt == maxChunkAttempts {
+				if err != nil {
+					return nil, err
+				}
+				// Let the caller decode the error of the last attempt.
+				return b, nil
+			}
+
+			tflog.Warn(ctx, "chunk upload failed, retrying", map[string]interface{}{
+				"upload_id":     uploadID,
+				"content_range": header.Get("Content-Range"),
+				"attempt":       attempt,
+				"status":        status,
+				"error":         fmt.Sprint(err),
+			})
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Duration(attempt) * chunkRetryDelay):
+			}
+		}
+
+		if status >= http.StatusBadRequest {
+			return b, nil
+		}
+
+		tflog.Info(ctx, "uploaded chunk", map[string]interface{}{
+			"upload_id":      uploadID,
+			"uploaded_bytes": end,
+			"total_bytes":    size,
+		})
+	}
+
+	return b, nil
+}
+
+// isRetryableStatus reports whether a request which failed with an HTTP
+// status can succeed when sent again.
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// randomUploadID returns a random identifier for the chunks of an upload.
+func randomUploadID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// postUpload sends a request to an Upload API endpoint and returns the status
+// and the body of the response.
+func postUpload(ctx context.Context, client *cloudinary.Cloudinary, endpoint string, body io.Reader, contentType string, header http.Header) (int, []byte, error) {
+	cfg := client.Upload.Config
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	req.Header.Set("User-Agent", api.GetUserAgent())
+	req.Header.Set("Content-Type", contentType)
+
+	if cfg.Cloud.OAuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.Cloud.OAuthToken)
+	}
+
+	if cfg.API.UploadTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.API.UploadTimeout)*time.Second)
+		defer cancel()
+	}
+
+	resp, err := client.Upload.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, nil, err
+	}
+	defer api.DeferredClose(resp.Body)
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, err
+	}
+
+	if !json.Valid(b) {
+		return resp.StatusCode, b, fmt.Errorf("unexpected response (HTTP %d): %s", resp.StatusCode, b)
+	}
+
+	return resp.StatusCode, b, nil
+}
+
+// postMultipart signs the parameters and sends them with the file as a
+// multipart form to an Upload API endpoint.
+func postMultipart(ctx context.Context, client *cloudinary.Cloudinary, endpoint string, params url.Values, name string, r io.Reader, header http.Header) (int, []byte, error) {
+	signed, err := signUploadParams(client, params)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	body, contentType := multipartBody(signed, name, r)
+	defer body.Close()
+
+	return postUpload(ctx, client, endpoint, body, contentType, header)
+}
+
+// multipartBody streams the parameters and the file as a multipart form, so
+// that the file is never held in memory. The body must be closed once the
+// request is sent, which stops the encoding if the body was not read.
+func multipartBody(params url.Values, name string, r io.Reader) (io.ReadCloser, string) {
+	pr, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(writeMultipart(w, params, name, r))
+	}()
+
+	return pr, w.FormDataContentType()
+}
+
+// writeMultipart writes the parameters and the file to a multipart form.
+func writeMultipart(w *multipart.Writer, params url.Values, name string, r io.Reader) error {
+	for k, values := range params {
+		for _, v := range values {
+			if err := w.WriteField(k, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	part, err := w.CreateFormFile("file", name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(part, r); err != nil {
+		return err
+	}
+
+	return w.Close()
+}
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go"
+)
+
+func TestUploadAsset_chunked(t *testing.T) {
+	chunkRetryDelay = 0
+
+	content := bytes.Repeat([]byte("0123456789"), 3)
+
+	var (
+		mu       sync.Mutex
+		ranges   []string
+		uploadID string
+		failed   bool
+		received []byte
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.URL.Path != "/v1_1/demo/image/upload" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+
+		// Every request is signed on its own, with every value of the
+		// array parameters.
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Error(err)
+			return
+		}
+
+		form := r.MultipartForm.Value
+		if got := form["tags[]"]; fmt.Sprint(got) != "[a b]" {
+			t.Errorf("tags[] = %v, want [a b]", got)
+		}
+
+		if len(form["signature"]) != 1 || len(form["timestamp"]) != 1 {
+			t.Errorf("signature = %v, timestamp = %v, want one of each", form["signature"], form["timestamp"])
+		}
+
+		h := sha1.Sum([]byte("tags=a,b&timestamp=" + r.FormValue("timestamp") + "secret"))
+		if want := hex.EncodeToString(h[:]); r.FormValue("signature") != want {
+			t.Errorf("signature = %s, want %s", r.FormValue("signature"), want)
+		}
+
+		id := r.Header.Get("X-Unique-Upload-Id")
+		if uploadID == "" {
+			uploadID = id
+		} else if id != uploadID {
+			t.Errorf("upload ID changed from %q to %q", uploadID, id)
+		}
+
+		contentRange := r.Header.Get("Content-Range")
+
+		// The second chunk fails once.
+		if contentRange == "bytes 12-23/30" && !failed {
+			failed = true
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error":{"message":"unavailable"}}`)
+			return
+		}
+
+		f, _, err := r.FormFile("file")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		b, _ := ioutil.ReadAll(f)
+
+		ranges = append(ranges, contentRange)
+		received = append(received, b...)
+
+		if contentRange == "bytes 24-29/30" {
+			fmt.Fprint(w, `{"public_id":"sample","bytes":30}`)
+			return
+		}
+		fmt.Fprint(w, `{"done":false}`)
+	}))
+	defer srv.Close()
+
+	client, err := cloudinary.NewFromParams("demo", "key", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Upload.Config.API.UploadPrefix = srv.URL
+	client.Upload.Config.API.ChunkSize = 12
+
+	var res struct {
+		PublicID string `json:"public_id"`
+		Bytes    int    `json:"bytes"`
+	}
+
+	params := url.Values{"tags[]": {"a", "b"}}
+
+	err = uploadAsset(context.Background(), client, 20, "image", assetFile{Content: content}, params, &res)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"bytes 0-11/30", "bytes 12-23/30", "bytes 24-29/30"}
+	if fmt.Sprint(ranges) != fmt.Sprint(want) {
+		t.Errorf("ranges = %v, want %v", ranges, want)
+	}
+
+	if !bytes.Equal(received, content) {
+		t.Errorf("received %q, want %q", received, content)
+	}
+
+	if res.PublicID != "sample" || res.Bytes != 30 {
+		t.Errorf("unexpected result %+v", res)
+	}
+
+	if len(params) != 1 {
+		t.Errorf("params = %v, want them left unsigned", params)
+	}
+}
+
+func TestUploadAsset_belowThreshold(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 3)
+
+	var requests int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.Header.Get("Content-Range") != "" {
+			t.Error("unexpected chunked upload")
+		}
+
+		if r.FormValue("signature") == "" {
+			t.Error("missing signature")
+		}
+
+		f, _, err := r.FormFile("file")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if b, _ := ioutil.ReadAll(f); !bytes.Equal(b, content) {
+			t.Errorf("received %q, want %q", b, content)
+		}
+
+		fmt.Fprint(w, `{"public_id":"sample"}`)
+	}))
+	defer srv.Close()
+
+	client, err := cloudinary.NewFromParams("demo", "key", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Upload.Config.API.UploadPrefix = srv.URL
+	client.Upload.Config.API.ChunkSize = 12
+
+	var res struct {
+		PublicID string `json:"public_id"`
+	}
+
+	err = uploadAsset(context.Background(), client, 30, "image", assetFile{Content: content}, url.Values{}, &res)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 1 || res.PublicID != "sample" {
+		t.Errorf("requests = %d, result = %+v", requests, res)
+	}
+}
+
+func TestSignUploadParams_array(t *testing.T) {
+	client, err := cloudinary.NewFromParams("demo", "key", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := url.Values{}
+	params.Set("metadata", "color=red")
+	params.Set("type", "upload")
+	params.Add("public_ids[]", "a")
+	params.Add("public_ids[]", "b")
+
+	signed, err := signUploadParams(client, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The server checks the signature of the array without the brackets.
+	h := sha1.Sum([]byte("metadata=color=red&public_ids=a,b&timestamp=" + signed.Get("timestamp") + "&type=upload" + "secret"))
+	if want := hex.EncodeToString(h[:]); signed.Get("signature") != want {
+		t.Errorf("signature = %s, want %s", signed.Get("signature"), want)
+	}
+
+	if got := signed["public_ids[]"]; len(got) != 2 {
+		t.Errorf("public_ids[] = %v, want the values to be sent as they are", got)
+	}
+}
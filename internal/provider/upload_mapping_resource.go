@@ -21,7 +21,7 @@ func (t uploadMappingResourceType) GetSchema(ctx context.Context) (tfsdk.Schema,
 
 		Attributes: map[string]tfsdk.Attribute{
 			"folder": {
-				MarkdownDescription: "The name of the folder.",
+				MarkdownDescription: "The name of the folder, which is the `public_id` prefix of mapped assets in both folder modes.",
 				Required:            true,
 				Type:                types.StringType,
 			},
@@ -0,0 +1,614 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/api"
+	"github.com/cloudinary/cloudinary-go/api/admin"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type uploadPresetResourceType struct{}
+
+func (t uploadPresetResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Upload Preset resource.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"access_mode": {
+				MarkdownDescription: "The access mode of uploaded assets. Either `public` or `authenticated`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"asset_folder": {
+				MarkdownDescription: "The asset folder of uploaded assets in dynamic folder mode.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"allowed_formats": {
+				MarkdownDescription: "The file formats allowed for upload.",
+				Optional:            true,
+				Type:                types.ListType{ElemType: types.StringType},
+			},
+			"display_name": {
+				MarkdownDescription: "The display name of uploaded assets in dynamic folder mode.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"eager": {
+				MarkdownDescription: "The transformations to generate eagerly on upload. Conflicts with `eager_steps`.",
+				Optional:            true,
+				Type:                types.ListType{ElemType: types.StringType},
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					transformationPlanModifier{},
+				},
+				Validators: []tfsdk.AttributeValidator{
+					transformationValidator{},
+				},
+			},
+			"folder": {
+				MarkdownDescription: "The folder where uploaded assets are stored. In dynamic folder mode, it sets both the asset folder and the `public_id` prefix; use `asset_folder` to set the asset folder only.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"id": {
+				Type:     types.StringType,
+				Computed: true,
+			},
+			"moderation": {
+				MarkdownDescription: "The moderation type applied to uploaded assets (e.g. `manual`).",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"name": {
+				MarkdownDescription: "The name of the upload preset.",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"notification_url": {
+				MarkdownDescription: "The URL that receives the upload notification.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"overwrite": {
+				MarkdownDescription: "Whether to overwrite existing assets with the same public ID.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.BoolType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"tags": {
+				MarkdownDescription: "The tags assigned to uploaded assets.",
+				Optional:            true,
+				Type:                types.ListType{ElemType: types.StringType},
+			},
+			"transformation": {
+				MarkdownDescription: "The incoming transformation applied to uploaded assets. Conflicts with `transformation_step`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					transformationPlanModifier{},
+				},
+				Validators: []tfsdk.AttributeValidator{
+					transformationValidator{},
+				},
+			},
+			"unique_filename": {
+				MarkdownDescription: "Whether to add random characters to the public ID to make it unique.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.BoolType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"unsigned": {
+				MarkdownDescription: "Whether the upload preset can be used for unsigned uploads.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.BoolType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"use_asset_folder_as_public_id_prefix": {
+				MarkdownDescription: "Whether to prefix generated public IDs with the `asset_folder` in dynamic folder mode.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.BoolType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]tfsdk.Block{
+			"access_control":      accessControlBlock("The access types of the uploaded assets."),
+			"eager_steps":         transformationStepsListBlock("The transformations to generate eagerly on upload described with steps. Conflicts with `eager`."),
+			"transformation_step": transformationStepsBlock("The chained components of the incoming transformation. Conflicts with `transformation`."),
+		},
+	}, nil
+}
+
+func (t uploadPresetResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return uploadPresetResource{
+		provider: provider,
+	}, diags
+}
+
+type uploadPresetResourceData struct {
+	AccessControl                  []accessControlData           `tfsdk:"access_control"`
+	AccessMode                     types.String                  `tfsdk:"access_mode"`
+	AllowedFormats                 types.List                    `tfsdk:"allowed_formats"`
+	AssetFolder                    types.String                  `tfsdk:"asset_folder"`
+	DisplayName                    types.String                  `tfsdk:"display_name"`
+	Eager                          types.List                    `tfsdk:"eager"`
+	EagerSteps                     []transformationStepsListData `tfsdk:"eager_steps"`
+	Folder                         types.String                  `tfsdk:"folder"`
+	ID                             types.String                  `tfsdk:"id"`
+	Moderation                     types.String                  `tfsdk:"moderation"`
+	Name                           types.String                  `tfsdk:"name"`
+	NotificationURL                types.String                  `tfsdk:"notification_url"`
+	Overwrite                      types.Bool                    `tfsdk:"overwrite"`
+	Tags                           types.List                    `tfsdk:"tags"`
+	Transformation                 types.String                  `tfsdk:"transformation"`
+	TransformationSteps            []transformationStepData      `tfsdk:"transformation_step"`
+	UniqueFilename                 types.Bool                    `tfsdk:"unique_filename"`
+	Unsigned                       types.Bool                    `tfsdk:"unsigned"`
+	UseAssetFolderAsPublicIDPrefix types.Bool                    `tfsdk:"use_asset_folder_as_public_id_prefix"`
+}
+
+// uploadPresetResult is the result of the create and update upload preset
+// calls.
+type uploadPresetResult struct {
+	Message string        `json:"message"`
+	Name    string        `json:"name"`
+	Error   api.ErrorResp `json:"error,omitempty"`
+}
+
+type uploadPresetResource struct {
+	provider provider
+}
+
+func (r uploadPresetResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data uploadPresetResourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Transformation.Null && len(data.TransformationSteps) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("transformation"),
+			"Conflicting Attributes",
+			"Only one of transformation and transformation_step can be set.",
+		)
+	}
+
+	if !data.Eager.Null && len(data.EagerSteps) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("eager"),
+			"Conflicting Attributes",
+			"Only one of eager and eager_steps can be set.",
+		)
+	}
+
+	resp.Diagnostics.Append(validateAccessControl(path.Root("access_control"), data.AccessControl)...)
+}
+
+func (r uploadPresetResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config uploadPresetResourceData
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(warnDynamicFolderAttributes(ctx, r.provider.client, map[string]attr.Value{
+		"asset_folder":                         config.AssetFolder,
+		"display_name":                         config.DisplayName,
+		"use_asset_folder_as_public_id_prefix": config.UseAssetFolderAsPublicIDPrefix,
+	})...)
+}
+
+func (r uploadPresetResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data uploadPresetResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Name
+
+	params, diags := uploadPresetParams(ctx, data, false)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params["name"] = data.Name.Value
+
+	var res uploadPresetResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodPost, "upload_presets", params, &res)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create upload preset, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create upload preset, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r uploadPresetResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data uploadPresetResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Name
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r uploadPresetResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data uploadPresetResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Name
+
+	params, diags := uploadPresetParams(ctx, data, true)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var res uploadPresetResult
+
+	err := callAdminAPI(ctx, r.provider.client, http.MethodPut, api.BuildPath("upload_presets", data.Name.Value), params, &res)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update upload preset, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update upload preset, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r uploadPresetResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data uploadPresetResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := admin.DeleteUploadPresetParams{
+		Name: data.Name.Value,
+	}
+
+	res, err := r.provider.client.Admin.DeleteUploadPreset(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete upload preset, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete upload preset, got error: %s", res.Error.Message),
+		)
+		return
+	}
+}
+
+func (r uploadPresetResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+// read refreshes data with the upload preset settings stored by Cloudinary.
+func (r uploadPresetResource) read(ctx context.Context, data *uploadPresetResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	params := admin.GetUploadPresetParams{
+		Name: data.Name.Value,
+	}
+
+	res, err := r.provider.client.Admin.GetUploadPreset(ctx, params)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read upload preset, got error: %s", err),
+		)
+		return diags
+	}
+
+	if res.Error.Message != "" {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read upload preset, got error: %s", res.Error.Message),
+		)
+		return diags
+	}
+
+	settings, _ := res.Settings.(map[string]interface{})
+
+	data.Unsigned = types.Bool{Value: res.Unsigned}
+	data.AccessControl = flattenAccessControl(data.AccessControl, settings["access_control"])
+	data.AccessMode = settingString(settings, "access_mode")
+	data.AllowedFormats = settingList(settings, "allowed_formats", ",")
+	data.AssetFolder = settingString(settings, "asset_folder")
+	data.DisplayName = settingString(settings, "display_name")
+	data.Folder = settingString(settings, "folder")
+	data.Moderation = settingString(settings, "moderation")
+	data.NotificationURL = settingString(settings, "notification_url")
+	data.Overwrite = settingBool(settings, "overwrite", data.Overwrite)
+	data.Tags = settingList(settings, "tags", ",")
+	data.UniqueFilename = settingBool(settings, "unique_filename", data.UniqueFilename)
+	data.UseAssetFolderAsPublicIDPrefix = settingBool(settings, "use_asset_folder_as_public_id_prefix", data.UseAssetFolderAsPublicIDPrefix)
+
+	if v, ok := settings["transformation"]; ok && v != nil {
+		transformation := flattenTransformation(v)
+
+		if len(data.TransformationSteps) > 0 {
+			data.TransformationSteps = semanticTransformationSteps(ctx, data.TransformationSteps, transformation)
+		}
+
+		data.Transformation = semanticTransformation(data.Transformation, transformation)
+	} else {
+		data.Transformation = types.String{Null: true}
+		data.TransformationSteps = []transformationStepData{}
+	}
+
+	if data.TransformationSteps == nil {
+		data.TransformationSteps = []transformationStepData{}
+	}
+
+	var eager []string
+	switch v := settings["eager"].(type) {
+	case []interface{}:
+		for _, e := range v {
+			eager = append(eager, flattenTransformation(e))
+		}
+	case string:
+		if v != "" {
+			eager = strings.Split(v, "|")
+		}
+	}
+
+	if len(data.EagerSteps) > 0 {
+		data.EagerSteps = semanticTransformationStepsList(ctx, data.EagerSteps, eager)
+	} else {
+		data.Eager = semanticTransformationList(data.Eager, eager)
+		data.EagerSteps = []transformationStepsListData{}
+	}
+
+	return diags
+}
+
+// uploadPresetParams builds the Admin API parameters of an upload preset.
+// When clear is set, unset attributes are sent as empty values so that
+// existing settings are removed from the preset.
+func uploadPresetParams(ctx context.Context, data uploadPresetResourceData, clear bool) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	params := map[string]interface{}{}
+
+	transformation := data.Transformation
+	if len(data.TransformationSteps) > 0 {
+		s, d := expandTransformationSteps(ctx, data.TransformationSteps)
+		diags.Append(d...)
+		transformation = types.String{Value: s}
+	}
+
+	eager := data.Eager
+	if len(data.EagerSteps) > 0 {
+		transformations, d := expandTransformationList(ctx, data.Eager, data.EagerSteps)
+		diags.Append(d...)
+
+		eager = types.List{ElemType: types.StringType}
+		for _, t := range transformations {
+			eager.Elems = append(eager.Elems, types.String{Value: t})
+		}
+	}
+
+	strs := map[string]types.String{
+		"access_mode":      data.AccessMode,
+		"asset_folder":     data.AssetFolder,
+		"display_name":     data.DisplayName,
+		"folder":           data.Folder,
+		"moderation":       data.Moderation,
+		"notification_url": data.NotificationURL,
+		"transformation":   transformation,
+	}
+	for name, v := range strs {
+		if !v.Null && !v.Unknown {
+			params[name] = v.Value
+		} else if clear {
+			params[name] = ""
+		}
+	}
+
+	lists := map[string]struct {
+		value types.List
+		sep   string
+	}{
+		"allowed_formats": {data.AllowedFormats, ","},
+		"eager":           {eager, "|"},
+		"tags":            {data.Tags, ","},
+	}
+	for name, v := range lists {
+		if v.value.Null || v.value.Unknown {
+			if clear {
+				params[name] = ""
+			}
+			continue
+		}
+
+		var elems []string
+		diags.Append(v.value.ElementsAs(ctx, &elems, false)...)
+		params[name] = strings.Join(elems, v.sep)
+	}
+
+	if len(data.AccessControl) > 0 {
+		params["access_control"] = expandAccessControl(data.AccessControl)
+	} else if clear {
+		params["access_control"] = ""
+	}
+
+	bools := map[string]types.Bool{
+		"overwrite":                            data.Overwrite,
+		"unique_filename":                      data.UniqueFilename,
+		"unsigned":                             data.Unsigned,
+		"use_asset_folder_as_public_id_prefix": data.UseAssetFolderAsPublicIDPrefix,
+	}
+	for name, v := range bools {
+		if !v.Null && !v.Unknown {
+			params[name] = v.Value
+		}
+	}
+
+	return params, diags
+}
+
+func settingString(settings map[string]interface{}, name string) types.String {
+	v, ok := settings[name]
+	if !ok || v == nil {
+		return types.String{Null: true}
+	}
+
+	return types.String{Value: fmt.Sprint(v)}
+}
+
+// settingBool returns the boolean setting, keeping the current value when
+// Cloudinary omits the setting because it has the default value.
+func settingBool(settings map[string]interface{}, name string, current types.Bool) types.Bool {
+	switch v := settings[name].(type) {
+	case bool:
+		return types.Bool{Value: v}
+	case string:
+		return types.Bool{Value: v == "true" || v == "1"}
+	}
+
+	if current.Null || current.Unknown {
+		return types.Bool{Value: false}
+	}
+
+	return current
+}
+
+// settingList returns a list setting, which Cloudinary returns either as a
+// JSON array or as a string joined with sep.
+func settingList(settings map[string]interface{}, name string, sep string) types.List {
+	list := types.List{ElemType: types.StringType}
+
+	switch v := settings[name].(type) {
+	case []interface{}:
+		for _, e := range v {
+			list.Elems = append(list.Elems, types.String{Value: fmt.Sprint(e)})
+		}
+	case string:
+		if v == "" {
+			break
+		}
+		for _, e := range strings.Split(v, sep) {
+			list.Elems = append(list.Elems, types.String{Value: strings.TrimSpace(e)})
+		}
+	}
+
+	if len(list.Elems) == 0 {
+		list.Null = true
+	}
+
+	return list
+}
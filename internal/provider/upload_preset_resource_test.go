@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccUploadPresetResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccUploadPresetResourceConfig("example"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_upload_preset.test", "name", "terraform-acc-test"),
+					resource.TestCheckResourceAttr("cloudinary_upload_preset.test", "folder", "example"),
+					resource.TestCheckResourceAttr("cloudinary_upload_preset.test", "unsigned", "true"),
+					resource.TestCheckResourceAttr("cloudinary_upload_preset.test", "tags.#", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "cloudinary_upload_preset.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccUploadPresetResourceConfig("example-updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_upload_preset.test", "folder", "example-updated"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccUploadPresetResourceConfig(folder string) string {
+	return fmt.Sprintf(`
+resource "cloudinary_upload_preset" "test" {
+  name     = "terraform-acc-test"
+  unsigned = true
+  folder   = %[1]q
+  tags     = ["example", "terraform"]
+}
+`, folder)
+}
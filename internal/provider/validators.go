@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringInValidator validates that a string attribute is one of a fixed set
+// of values. It accepts string attributes and sets of strings.
+type stringInValidator struct {
+	values []string
+}
+
+func (v stringInValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v stringInValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: `%s`", strings.Join(v.values, "`, `"))
+}
+
+func (v stringInValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	switch value := req.AttributeConfig.(type) {
+	case types.String:
+		v.validate(ctx, req.AttributePath, value, resp)
+	case types.Set:
+		if value.Null || value.Unknown {
+			return
+		}
+		for _, e := range value.Elems {
+			if s, ok := e.(types.String); ok {
+				v.validate(ctx, req.AttributePath.AtSetValue(s), s, resp)
+			}
+		}
+	}
+}
+
+func (v stringInValidator) validate(ctx context.Context, p path.Path, value types.String, resp *tfsdk.ValidateAttributeResponse) {
+	if value.Null || value.Unknown {
+		return
+	}
+
+	for _, s := range v.values {
+		if value.Value == s {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		p,
+		"Invalid Attribute Value",
+		fmt.Sprintf("The value %q is invalid, %s.", value.Value, v.Description(ctx)),
+	)
+}
+
+// int64AtLeastValidator validates that an integer attribute is not less than
+// a minimum value.
+type int64AtLeastValidator struct {
+	min int64
+}
+
+func (v int64AtLeastValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be at least %d", v.min)
+}
+
+func (v int64AtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be at least `%d`", v.min)
+}
+
+func (v int64AtLeastValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	value, ok := req.AttributeConfig.(types.Int64)
+	if !ok || value.Null || value.Unknown {
+		return
+	}
+
+	if value.Value >= v.min {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.AttributePath,
+		"Invalid Attribute Value",
+		fmt.Sprintf("The value %d is invalid, %s.", value.Value, v.Description(ctx)),
+	)
+}
+
+// rfc3339Validator validates that a string attribute is an RFC 3339
+// timestamp.
+type rfc3339Validator struct{}
+
+func (v rfc3339Validator) Description(ctx context.Context) string {
+	return "value must be an RFC 3339 timestamp"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an RFC 3339 timestamp, e.g. `2022-10-01T00:00:00Z`"
+}
+
+func (v rfc3339Validator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	value, ok := req.AttributeConfig.(types.String)
+	if !ok || value.Null || value.Unknown {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, value.Value); err == nil {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.AttributePath,
+		"Invalid Attribute Value",
+		fmt.Sprintf("The value %q is invalid, %s.", value.Value, v.Description(ctx)),
+	)
+}
+
+// durationValidator validates that a string attribute is a positive
+// duration, e.g. `30m`.
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a positive duration, e.g. `30m` or `1h30m`"
+}
+
+func (v durationValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	value, ok := req.AttributeConfig.(types.String)
+	if !ok || value.Null || value.Unknown {
+		return
+	}
+
+	if d, err := time.ParseDuration(value.Value); err == nil && d > 0 {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.AttributePath,
+		"Invalid Attribute Value",
+		fmt.Sprintf("The value %q is invalid, %s.", value.Value, v.Description(ctx)),
+	)
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestStringInValidator(t *testing.T) {
+	ctx := context.Background()
+	v := stringInValidator{values: []string{"enum", "set"}}
+
+	tests := []struct {
+		value types.String
+		valid bool
+	}{
+		{types.String{Value: "enum"}, true},
+		{types.String{Value: "set"}, true},
+		{types.String{Value: "Set"}, false},
+		{types.String{Value: ""}, false},
+		{types.String{Null: true}, true},
+		{types.String{Unknown: true}, true},
+	}
+
+	for _, tt := range tests {
+		req := tfsdk.ValidateAttributeRequest{
+			AttributePath:   path.Root("type"),
+			AttributeConfig: tt.value,
+		}
+		resp := tfsdk.ValidateAttributeResponse{}
+
+		v.Validate(ctx, req, &resp)
+
+		if got := !resp.Diagnostics.HasError(); got != tt.valid {
+			t.Errorf("Validate(%s) valid = %t, want %t", tt.value, got, tt.valid)
+		}
+	}
+}
+
+func TestStringInValidatorSet(t *testing.T) {
+	ctx := context.Background()
+	v := stringInValidator{values: []string{"m3u8", "mpd"}}
+
+	tests := []struct {
+		value types.Set
+		valid bool
+	}{
+		{stringSet([]string{"m3u8", "mpd"}), true},
+		{stringSet([]string{"m3u8", "mp4"}), false},
+		{stringSet(nil), true},
+		{types.Set{ElemType: types.StringType, Null: true}, true},
+		{types.Set{ElemType: types.StringType, Unknown: true}, true},
+	}
+
+	for _, tt := range tests {
+		req := tfsdk.ValidateAttributeRequest{
+			AttributePath:   path.Root("formats"),
+			AttributeConfig: tt.value,
+		}
+		resp := tfsdk.ValidateAttributeResponse{}
+
+		v.Validate(ctx, req, &resp)
+
+		if got := !resp.Diagnostics.HasError(); got != tt.valid {
+			t.Errorf("Validate(%s) valid = %t, want %t", tt.value, got, tt.valid)
+		}
+	}
+}
+
+func TestInt64AtLeastValidator(t *testing.T) {
+	ctx := context.Background()
+	v := int64AtLeastValidator{min: 5}
+
+	tests := []struct {
+		value types.Int64
+		valid bool
+	}{
+		{types.Int64{Value: 5}, true},
+		{types.Int64{Value: 6}, true},
+		{types.Int64{Value: 4}, false},
+		{types.Int64{Value: -1}, false},
+		{types.Int64{Null: true}, true},
+		{types.Int64{Unknown: true}, true},
+	}
+
+	for _, tt := range tests {
+		req := tfsdk.ValidateAttributeRequest{
+			AttributePath:   path.Root("size"),
+			AttributeConfig: tt.value,
+		}
+		resp := tfsdk.ValidateAttributeResponse{}
+
+		v.Validate(ctx, req, &resp)
+
+		if got := !resp.Diagnostics.HasError(); got != tt.valid {
+			t.Errorf("Validate(%s) valid = %t, want %t", tt.value, got, tt.valid)
+		}
+	}
+}
+
+func TestRFC3339Validator(t *testing.T) {
+	ctx := context.Background()
+	v := rfc3339Validator{}
+
+	tests := []struct {
+		value types.String
+		valid bool
+	}{
+		{types.String{Value: "2022-10-01T00:00:00Z"}, true},
+		{types.String{Value: "2022-10-01T09:00:00+09:00"}, true},
+		{types.String{Value: "2022-10-01"}, false},
+		{types.String{Value: "2022-10-01 00:00:00"}, false},
+		{types.String{Null: true}, true},
+		{types.String{Unknown: true}, true},
+	}
+
+	for _, tt := range tests {
+		req := tfsdk.ValidateAttributeRequest{
+			AttributePath:   path.Root("access_control").AtListIndex(0).AtName("start"),
+			AttributeConfig: tt.value,
+		}
+		resp := tfsdk.ValidateAttributeResponse{}
+
+		v.Validate(ctx, req, &resp)
+
+		if got := !resp.Diagnostics.HasError(); got != tt.valid {
+			t.Errorf("Validate(%s) valid = %t, want %t", tt.value, got, tt.valid)
+		}
+	}
+}
+
+func TestDurationValidator(t *testing.T) {
+	ctx := context.Background()
+	v := durationValidator{}
+
+	tests := []struct {
+		value types.String
+		valid bool
+	}{
+		{types.String{Value: "30m"}, true},
+		{types.String{Value: "1h30m"}, true},
+		{types.String{Value: "0s"}, false},
+		{types.String{Value: "-5m"}, false},
+		{types.String{Value: "30"}, false},
+		{types.String{Null: true}, true},
+		{types.String{Unknown: true}, true},
+	}
+
+	for _, tt := range tests {
+		req := tfsdk.ValidateAttributeRequest{
+			AttributePath:   path.Root("timeouts").AtName("create"),
+			AttributeConfig: tt.value,
+		}
+		resp := tfsdk.ValidateAttributeResponse{}
+
+		v.Validate(ctx, req, &resp)
+
+		if got := !resp.Diagnostics.HasError(); got != tt.valid {
+			t.Errorf("Validate(%s) valid = %t, want %t", tt.value, got, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,396 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// hlsFormat is the format of HLS manifests.
+	hlsFormat = "m3u8"
+	// dashFormat is the format of MPEG-DASH manifests.
+	dashFormat = "mpd"
+)
+
+type videoStreamingResourceType struct{}
+
+func (t videoStreamingResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Video Streaming resource. Generates the adaptive streaming manifests and renditions of an existing video for a streaming profile, and waits until they are ready. Destroying the resource deletes the manifests.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"dash_url": {
+				MarkdownDescription: "The HTTPS delivery URL of the MPEG-DASH manifest, when `mpd` is one of the `formats`.",
+				Computed:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"formats": {
+				MarkdownDescription: "The manifest formats to generate: `m3u8` for HLS and `mpd` for MPEG-DASH. Defaults to both.",
+				Optional:            true,
+				Type:                types.SetType{ElemType: types.StringType},
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplaceIf(
+						manifestFormatsChanged,
+						"Requires replacement when the generated manifest formats change.",
+						"Requires replacement when the generated manifest formats change.",
+					),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{hlsFormat, dashFormat}},
+				},
+			},
+			"hls_url": {
+				MarkdownDescription: "The HTTPS delivery URL of the HLS manifest, when `m3u8` is one of the `formats`.",
+				Computed:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"id": {
+				MarkdownDescription: "The ID of the video in the `video/type/public_id` form.",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"public_id": {
+				MarkdownDescription: "The public ID of the video.",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"streaming_profile": {
+				MarkdownDescription: "The name of the streaming profile, either predefined (e.g. `hd`) or managed with `cloudinary_streaming_profile`.",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"timeouts": timeoutsAttribute(),
+			"type": {
+				MarkdownDescription: "The delivery type of the video. One of `upload` (default), `private` or `authenticated`.",
+				Computed:            true,
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringInValidator{values: []string{"authenticated", "private", "upload"}},
+				},
+			},
+		},
+	}, nil
+}
+
+func (t videoStreamingResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return videoStreamingResource{
+		provider: provider,
+	}, diags
+}
+
+type videoStreamingResourceData struct {
+	DashURL          types.String  `tfsdk:"dash_url"`
+	Formats          types.Set     `tfsdk:"formats"`
+	HLSURL           types.String  `tfsdk:"hls_url"`
+	ID               types.String  `tfsdk:"id"`
+	PublicID         types.String  `tfsdk:"public_id"`
+	StreamingProfile types.String  `tfsdk:"streaming_profile"`
+	Timeouts         *timeoutsData `tfsdk:"timeouts"`
+	Type             types.String  `tfsdk:"type"`
+}
+
+// setDefaults fills the optional attributes which are not configured with
+// their default values.
+func (data *videoStreamingResourceData) setDefaults() {
+	if data.Type.Null || data.Type.Unknown {
+		data.Type = types.String{Value: "upload"}
+	}
+}
+
+// manifestFormats returns the sorted manifest formats of the formats
+// attribute, which are both formats when it is not configured.
+func manifestFormats(ctx context.Context, formats types.Set) ([]string, diag.Diagnostics) {
+	if formats.Null || formats.Unknown {
+		return []string{hlsFormat, dashFormat}, nil
+	}
+
+	var values []string
+
+	diags := formats.ElementsAs(ctx, &values, false)
+
+	sort.Strings(values)
+
+	return values, diags
+}
+
+// manifestFormatsChanged reports whether the manifest formats differ between
+// the state and the configuration, where removing formats which were set to
+// both formats is no change.
+func manifestFormatsChanged(ctx context.Context, state, config attr.Value, p path.Path) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	stateSet, ok := state.(types.Set)
+	if !ok {
+		return true, diags
+	}
+
+	configSet, ok := config.(types.Set)
+	if !ok || configSet.Unknown {
+		return true, diags
+	}
+
+	current, d := manifestFormats(ctx, stateSet)
+	diags.Append(d...)
+
+	planned, d := manifestFormats(ctx, configSet)
+	diags.Append(d...)
+
+	return !reflect.DeepEqual(current, planned), diags
+}
+
+// eager returns the eager transformations which generate the manifests, one
+// for each format.
+func (data videoStreamingResourceData) eager(ctx context.Context) ([]eagerTransformation, diag.Diagnostics) {
+	formats, diags := manifestFormats(ctx, data.Formats)
+
+	eager := make([]eagerTransformation, len(formats))
+	for i, f := range formats {
+		eager[i] = eagerTransformation{Format: f, Transformation: "sp_" + data.StreamingProfile.Value}
+	}
+
+	return eager, diags
+}
+
+// setManifests stores the URLs of the manifests.
+func (data *videoStreamingResourceData) setManifests(manifests []derivedResource) {
+	data.DashURL = types.String{Null: true}
+	data.HLSURL = types.String{Null: true}
+
+	for _, m := range manifests {
+		switch m.Format {
+		case dashFormat:
+			data.DashURL = types.String{Value: m.SecureURL}
+		case hlsFormat:
+			data.HLSURL = types.String{Value: m.SecureURL}
+		}
+	}
+}
+
+type videoStreamingResource struct {
+	provider provider
+}
+
+func (r videoStreamingResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data videoStreamingResourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Formats.Null && !data.Formats.Unknown && len(data.Formats.Elems) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("formats"),
+			"Invalid Attribute Value",
+			"At least one manifest format must be set.",
+		)
+	}
+}
+
+func (r videoStreamingResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data videoStreamingResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setDefaults()
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.createTimeout())
+	defer cancel()
+
+	eager, diags := data.eager(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Adaptive streaming is only generated in the background.
+	res, err := generateDerivedResources(ctx, r.provider.client, "video", data.Type.Value, data.PublicID.Value, eager, true)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to generate streaming manifests, got error: %s", err),
+		)
+		return
+	}
+
+	if res.Error.Message != "" {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to generate streaming manifests, got error: %s", res.Error.Message),
+		)
+		return
+	}
+
+	manifests, err := waitForDerivedResources(ctx, r.provider.client, "video", data.Type.Value, data.PublicID.Value, eager)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to generate streaming manifests, got error: %s", err),
+		)
+		return
+	}
+
+	data.ID = types.String{Value: assetID("video", data.Type.Value, data.PublicID.Value)}
+	data.setManifests(manifests)
+
+	tflog.Trace(ctx, "created a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r videoStreamingResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data videoStreamingResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	manifests, found, diags := r.manifests(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Manifests deleted outside of Terraform are generated again.
+	if !found {
+		tflog.Warn(ctx, "streaming manifests not found, removing them from the state", map[string]interface{}{
+			"id": data.ID.Value,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.setManifests(manifests)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r videoStreamingResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data videoStreamingResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.setDefaults()
+
+	// Only the timeouts can change in place, and they only matter when the
+	// manifests are generated.
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r videoStreamingResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data videoStreamingResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	manifests, _, diags := r.manifests(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids := make([]string, len(manifests))
+	for i, m := range manifests {
+		ids[i] = m.ID
+	}
+
+	if err := deleteDerivedResources(ctx, r.provider.client, ids); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete streaming manifests, got error: %s", err),
+		)
+		return
+	}
+}
+
+// manifests returns the manifests of the video which exist. found is false
+// when the video or any of the manifests is missing.
+func (r videoStreamingResource) manifests(ctx context.Context, data videoStreamingResourceData) ([]derivedResource, bool, diag.Diagnostics) {
+	eager, diags := data.eager(ctx)
+
+	if diags.HasError() {
+		return nil, false, diags
+	}
+
+	res, err := getDerivedResources(ctx, r.provider.client, "video", data.Type.Value, data.PublicID.Value)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read streaming manifests, got error: %s", err),
+		)
+		return nil, false, diags
+	}
+
+	if isNotFoundError(res.Error.Message) {
+		return nil, false, diags
+	}
+
+	if res.Error.Message != "" {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read streaming manifests, got error: %s", res.Error.Message),
+		)
+		return nil, false, diags
+	}
+
+	manifests, missing := matchDerivedResources(eager, res.Derived)
+
+	return manifests, len(missing) == 0, diags
+}
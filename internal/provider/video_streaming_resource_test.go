@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVideoStreamingResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccVideoStreamingResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudinary_video_streaming.test", "id", "video/upload/terraform_acc_test/streaming"),
+					resource.TestCheckResourceAttr("cloudinary_video_streaming.test", "formats.#", "1"),
+					resource.TestCheckResourceAttrSet("cloudinary_video_streaming.test", "hls_url"),
+					resource.TestCheckNoResourceAttr("cloudinary_video_streaming.test", "dash_url"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+const testAccVideoStreamingResourceConfig = `
+resource "cloudinary_asset" "test" {
+  source_url    = "https://res.cloudinary.com/demo/video/upload/dog.mp4"
+  public_id     = "terraform_acc_test/streaming"
+  resource_type = "video"
+}
+
+resource "cloudinary_video_streaming" "test" {
+  public_id         = cloudinary_asset.test.public_id
+  streaming_profile = "sd"
+  formats           = ["m3u8"]
+
+  timeouts = {
+    create = "10m"
+  }
+}
+`
+
+func TestVideoStreamingEager(t *testing.T) {
+	data := videoStreamingResourceData{
+		Formats:          types.Set{ElemType: types.StringType, Null: true},
+		StreamingProfile: types.String{Value: "hd"},
+	}
+	data.setDefaults()
+
+	eager, diags := data.eager(context.Background())
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	var got []string
+	for _, e := range eager {
+		got = append(got, e.String())
+	}
+
+	if want := []string{"sp_hd/m3u8", "sp_hd/mpd"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("eager() = %v, want %v", got, want)
+	}
+
+	data.setManifests([]derivedResource{{Format: "m3u8", SecureURL: "https://example.com/sp_hd/movie.m3u8"}})
+
+	if data.HLSURL.Value != "https://example.com/sp_hd/movie.m3u8" {
+		t.Errorf("hls_url = %s, want the manifest URL", data.HLSURL)
+	}
+
+	if !data.DashURL.Null {
+		t.Errorf("dash_url = %s, want null", data.DashURL)
+	}
+}
+
+func TestManifestFormatsChanged(t *testing.T) {
+	null := types.Set{ElemType: types.StringType, Null: true}
+
+	tests := []struct {
+		state, config types.Set
+		want          bool
+	}{
+		{state: stringSet([]string{"mpd", "m3u8"}), config: null, want: false},
+		{state: null, config: stringSet([]string{"m3u8", "mpd"}), want: false},
+		{state: stringSet([]string{"m3u8"}), config: null, want: true},
+		{state: null, config: stringSet([]string{"mpd"}), want: true},
+		{state: stringSet([]string{"m3u8"}), config: stringSet([]string{"m3u8"}), want: false},
+	}
+
+	for _, tt := range tests {
+		got, diags := manifestFormatsChanged(context.Background(), tt.state, tt.config, path.Root("formats"))
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+
+		if got != tt.want {
+			t.Errorf("manifestFormatsChanged(%v, %v) = %v, want %v", tt.state, tt.config, got, tt.want)
+		}
+	}
+}